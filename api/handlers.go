@@ -1,13 +1,14 @@
 package api
 
 import (
+	"bytes"
 	"compress/gzip"
+	"encoding/json"
 	"encoding/xml"
 	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
-	"unsafe"
 
 	"github.com/gin-gonic/gin"
 	"github.com/micpst/minisearch/pkg/store"
@@ -15,14 +16,36 @@ import (
 )
 
 type SearchRequest struct {
-	Query      string             `json:"query" binding:"required"`
+	Query      string             `json:"query"`
 	Properties []string           `json:"properties"`
+	Boost      map[string]float64 `json:"boost"`
 	Exact      bool               `json:"exact"`
 	Tolerance  int                `json:"tolerance"`
 	Relevance  BM25Params         `json:"relevance"`
+	Penalties  PenaltyParams      `json:"penalties"`
+	Scoring    store.Scoring      `json:"scoring"`
 	Offset     int                `json:"offset"`
 	Limit      int                `json:"limit"`
 	Language   tokenizer.Language `json:"lang"`
+	Explain    bool               `json:"explain"`
+	Filters    Filters            `json:"filters"`
+	Facets     map[string]Facet   `json:"facets"`
+	Sort       []SortParams       `json:"sort"`
+	Vector     *VectorParams      `json:"vector"`
+	Hybrid     HybridParams       `json:"hybrid"`
+	Highlight  *HighlightParams   `json:"highlight"`
+	Suggest    *SpellingParams    `json:"suggest"`
+}
+
+// maxSuggestTolerance bounds the typos of a prefix, every keystroke would otherwise walk most of the index.
+const maxSuggestTolerance = 2
+
+type SuggestRequest struct {
+	Query      string             `json:"query"`
+	Properties []string           `json:"properties"`
+	Tolerance  int                `json:"tolerance"`
+	Limit      int                `json:"limit"`
+	Language   tokenizer.Language `json:"lang"`
 }
 
 type BM25Params struct {
@@ -31,23 +54,160 @@ type BM25Params struct {
 	D float64 `json:"d"`
 }
 
+type Filters struct {
+	Range map[string]RangeFilter `json:"range"`
+	Term  map[string]any         `json:"term"`
+	Terms map[string][]any       `json:"terms"`
+}
+
+// UnmarshalJSON rejects the unknown filter kinds, such as properties given directly under filters,
+// which would otherwise leave the results unfiltered.
+func (f *Filters) UnmarshalJSON(data []byte) error {
+	type filters Filters
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	return d.Decode((*filters)(f))
+}
+
+type RangeFilter struct {
+	Eq      *float64    `json:"eq"`
+	Gt      *float64    `json:"gt"`
+	Gte     *float64    `json:"gte"`
+	Lt      *float64    `json:"lt"`
+	Lte     *float64    `json:"lte"`
+	Between *[2]float64 `json:"between"`
+}
+
+type VectorParams struct {
+	Property string    `json:"property"`
+	Value    []float32 `json:"value"`
+	K        int       `json:"k"`
+	Ef       int       `json:"ef"`
+}
+
+type HybridParams struct {
+	Fusion       store.Fusion `json:"fusion"`
+	Alpha        *float64     `json:"alpha"`
+	RankConstant int          `json:"rankConstant"`
+}
+
+type HighlightParams struct {
+	Properties   []string `json:"properties"`
+	PreTag       string   `json:"preTag"`
+	PostTag      string   `json:"postTag"`
+	FragmentSize int      `json:"fragmentSize"`
+	Fragments    int      `json:"fragments"`
+}
+
+type SpellingParams struct {
+	MaxDistance  int  `json:"maxDistance"`
+	MinFrequency int  `json:"minFrequency"`
+	AutoCorrect  bool `json:"autoCorrect"`
+}
+
+type HybridScores struct {
+	Text       float64 `json:"text"`
+	TextRank   int     `json:"textRank,omitempty"`
+	Vector     float64 `json:"vector"`
+	VectorRank int     `json:"vectorRank,omitempty"`
+}
+
+type SortParams struct {
+	Field string          `json:"field"`
+	Order store.SortOrder `json:"order"`
+}
+
+type Facet struct {
+	Limit  int          `json:"limit"`
+	Ranges []FacetRange `json:"ranges"`
+}
+
+type FacetRange struct {
+	From *float64 `json:"from,omitempty"`
+	To   *float64 `json:"to,omitempty"`
+}
+
+type FacetResult struct {
+	Count  int               `json:"count,omitempty"`
+	Values []FacetValue      `json:"values,omitempty"`
+	Ranges []FacetRangeCount `json:"ranges,omitempty"`
+}
+
+type FacetValue struct {
+	Value any `json:"value"`
+	Count int `json:"count"`
+}
+
+type FacetRangeCount struct {
+	From  *float64 `json:"from,omitempty"`
+	To    *float64 `json:"to,omitempty"`
+	Count int      `json:"count"`
+}
+
+type PenaltyParams struct {
+	Edit   float64 `json:"edit"`
+	Prefix float64 `json:"prefix"`
+}
+
 type DocumentResponse struct {
-	Id       string `json:"id"`
-	Title    string `json:"title"`
-	Url      string `json:"url"`
-	Abstract string `json:"abstract"`
+	Id         string  `json:"id"`
+	Title      string  `json:"title"`
+	Url        string  `json:"url"`
+	Abstract   string  `json:"abstract"`
+	Language   string  `json:"lang,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
 }
 
 type SearchDocument struct {
-	Id    string   `json:"id"`
-	Data  Document `json:"data"`
-	Score float64  `json:"score"`
+	Id          string              `json:"id"`
+	Data        Document            `json:"data"`
+	Score       float64             `json:"score"`
+	Explanation *Explanation        `json:"explanation,omitempty"`
+	Hybrid      *HybridScores       `json:"hybrid,omitempty"`
+	Highlights  map[string][]string `json:"highlights,omitempty"`
+}
+
+type Explanation struct {
+	Score       float64        `json:"score"`
+	Description string         `json:"description"`
+	Token       string         `json:"token,omitempty"`
+	Term        string         `json:"term,omitempty"`
+	Penalty     float64        `json:"penalty,omitempty"`
+	Fields      []FieldStats   `json:"fields,omitempty"`
+	Relevance   *BM25Params    `json:"relevance,omitempty"`
+	Details     []*Explanation `json:"details,omitempty"`
+}
+
+type FieldStats struct {
+	Property          string  `json:"property"`
+	TermFrequency     float64 `json:"tf"`
+	DocumentFrequency int     `json:"df"`
+	FieldLength       int     `json:"fieldLength"`
+	AvgFieldLength    float64 `json:"avgFieldLength"`
+	Boost             float64 `json:"boost"`
 }
 
 type SearchDocumentResponse struct {
-	Count   int              `json:"count"`
-	Hits    []SearchDocument `json:"hits"`
-	Elapsed int64            `json:"elapsed"`
+	Count      int                    `json:"count"`
+	Hits       []SearchDocument       `json:"hits"`
+	Facets     map[string]FacetResult `json:"facets,omitempty"`
+	DidYouMean string                 `json:"didYouMean,omitempty"`
+	Corrected  bool                   `json:"corrected,omitempty"`
+	Language   tokenizer.Language     `json:"lang,omitempty"`
+	Confidence float64                `json:"confidence,omitempty"`
+	Elapsed    int64                  `json:"elapsed"`
+}
+
+type Suggestion struct {
+	Text     string `json:"text"`
+	Term     string `json:"term"`
+	Count    int    `json:"count"`
+	Distance int    `json:"distance"`
+}
+
+type SuggestResponse struct {
+	Suggestions []Suggestion `json:"suggestions"`
+	Elapsed     int64        `json:"elapsed"`
 }
 
 type ErrorResponse struct {
@@ -113,10 +273,12 @@ func (s *Server) createDocument(c *gin.Context) {
 	switch err.(type) {
 	case nil:
 		c.JSON(http.StatusCreated, DocumentResponse{
-			Id:       doc.Id,
-			Title:    doc.Data.Title,
-			Url:      doc.Data.Url,
-			Abstract: doc.Data.Abstract,
+			Id:         doc.Id,
+			Title:      doc.Data.Title,
+			Url:        doc.Data.Url,
+			Abstract:   doc.Data.Abstract,
+			Language:   string(doc.Language),
+			Confidence: doc.Confidence,
 		})
 	default:
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -140,10 +302,12 @@ func (s *Server) updateDocument(c *gin.Context) {
 	switch err.(type) {
 	case nil:
 		c.JSON(http.StatusOK, DocumentResponse{
-			Id:       doc.Id,
-			Title:    doc.Data.Title,
-			Url:      doc.Data.Url,
-			Abstract: doc.Data.Abstract,
+			Id:         doc.Id,
+			Title:      doc.Data.Title,
+			Url:        doc.Data.Url,
+			Abstract:   doc.Data.Abstract,
+			Language:   string(doc.Language),
+			Confidence: doc.Confidence,
 		})
 	case *store.DocumentNotFoundError:
 		c.JSON(http.StatusNotFound, ErrorResponse{
@@ -158,8 +322,7 @@ func (s *Server) updateDocument(c *gin.Context) {
 
 func (s *Server) deleteDocument(c *gin.Context) {
 	err := s.db.Delete(&store.DeleteParams[Document]{
-		Id:       c.Param("id"),
-		Language: tokenizer.Language(strings.ToLower(c.Query("lang"))),
+		Id: c.Param("id"),
 	})
 
 	switch err.(type) {
@@ -185,29 +348,109 @@ func (s *Server) searchDocuments(c *gin.Context) {
 			B: 0.75,
 			D: 0.5,
 		},
+		Penalties: PenaltyParams{
+			Edit:   0.5,
+			Prefix: 0.3,
+		},
+		Hybrid: HybridParams{
+			Fusion:       store.RRF,
+			RankConstant: 60,
+		},
 	}
 	if err := c.BindJSON(&params); err != nil {
 		return
 	}
 
+	ranges := make(map[string]store.RangeFilter, len(params.Filters.Range))
+	for prop, filter := range params.Filters.Range {
+		ranges[prop] = store.RangeFilter(filter)
+	}
+
+	facets := make(map[string]store.FacetParams, len(params.Facets))
+	for prop, facet := range params.Facets {
+		facetRanges := make([]store.FacetRange, len(facet.Ranges))
+		for i, r := range facet.Ranges {
+			facetRanges[i] = store.FacetRange(r)
+		}
+		facets[prop] = store.FacetParams{
+			Limit:  facet.Limit,
+			Ranges: facetRanges,
+		}
+	}
+
+	sortParams := make([]store.SortParams, len(params.Sort))
+	for i, p := range params.Sort {
+		sortParams[i] = store.SortParams(p)
+	}
+
 	start := time.Now()
 	result, err := s.db.Search(&store.SearchParams{
 		Query:      params.Query,
 		Properties: params.Properties,
+		Boost:      params.Boost,
 		Exact:      params.Exact,
 		Tolerance:  params.Tolerance,
 		Relevance:  store.BM25Params(params.Relevance),
+		Penalties:  store.PenaltyParams(params.Penalties),
+		Scoring:    params.Scoring,
 		Offset:     params.Offset,
 		Limit:      params.Limit,
+		Language:   tokenizer.Language(strings.ToLower(string(params.Language))),
+		Explain:    params.Explain,
+		Filters: store.Filters{
+			Range: ranges,
+			Term:  params.Filters.Term,
+			Terms: params.Filters.Terms,
+		},
+		Facets:    facets,
+		Sort:      sortParams,
+		Vector:    (*store.VectorParams)(params.Vector),
+		Hybrid:    store.HybridParams(params.Hybrid),
+		Highlight: (*store.HighlightParams)(params.Highlight),
+		Spelling:  (*store.SpellingParams)(params.Suggest),
 	})
 	elapsed := time.Since(start)
 
 	switch err.(type) {
 	case nil:
+		hits := make([]SearchDocument, len(result.Hits))
+		for i, hit := range result.Hits {
+			hits[i] = SearchDocument{
+				Id:          hit.Id,
+				Data:        hit.Data,
+				Score:       hit.Score,
+				Explanation: newExplanation(hit.Explanation),
+				Hybrid:      (*HybridScores)(hit.Hybrid),
+				Highlights:  hit.Highlights,
+			}
+		}
+
+		facetResults := make(map[string]FacetResult, len(result.Facets))
+		for prop, facet := range result.Facets {
+			values := make([]FacetValue, len(facet.Values))
+			for i, v := range facet.Values {
+				values[i] = FacetValue(v)
+			}
+			rangeCounts := make([]FacetRangeCount, len(facet.Ranges))
+			for i, r := range facet.Ranges {
+				rangeCounts[i] = FacetRangeCount(r)
+			}
+			facetResults[prop] = FacetResult{
+				Count:  facet.Count,
+				Values: values,
+				Ranges: rangeCounts,
+			}
+		}
+
 		c.JSON(http.StatusOK, SearchDocumentResponse{
-			Count:   result.Count,
-			Hits:    *(*[]SearchDocument)(unsafe.Pointer(&result.Hits)),
-			Elapsed: elapsed.Microseconds(),
+			Count:      result.Count,
+			Hits:       hits,
+			Facets:     facetResults,
+			DidYouMean: result.DidYouMean,
+			Corrected:  result.Corrected,
+			Language:   result.Language,
+			Confidence: result.Confidence,
+			Elapsed:    elapsed.Microseconds(),
 		})
 	default:
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -216,6 +459,69 @@ func (s *Server) searchDocuments(c *gin.Context) {
 	}
 }
 
+func (s *Server) suggest(c *gin.Context) {
+	params := SuggestRequest{
+		Properties: []string{},
+		Limit:      5,
+	}
+	if err := c.BindJSON(&params); err != nil {
+		return
+	}
+
+	start := time.Now()
+	suggestions, err := s.db.Suggest(&store.SuggestParams{
+		Query:      params.Query,
+		Properties: params.Properties,
+		Tolerance:  min(max(params.Tolerance, 0), maxSuggestTolerance),
+		Limit:      params.Limit,
+		Language:   tokenizer.Language(strings.ToLower(string(params.Language))),
+	})
+	elapsed := time.Since(start)
+
+	switch err.(type) {
+	case nil:
+		response := make([]Suggestion, len(suggestions))
+		for i, suggestion := range suggestions {
+			response[i] = Suggestion(suggestion)
+		}
+		c.JSON(http.StatusOK, SuggestResponse{
+			Suggestions: response,
+			Elapsed:     elapsed.Microseconds(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: err.Error(),
+		})
+	}
+}
+
+// newExplanation converts the explanation of a hit and its details.
+func newExplanation(e *store.Explanation) *Explanation {
+	if e == nil {
+		return nil
+	}
+
+	fields := make([]FieldStats, len(e.Fields))
+	for i, f := range e.Fields {
+		fields[i] = FieldStats(f)
+	}
+	details := make([]*Explanation, len(e.Details))
+	for i, d := range e.Details {
+		details[i] = newExplanation(d)
+	}
+
+	return &Explanation{
+		Score:       e.Score,
+		Description: e.Description,
+		Token:       e.Token,
+		Term:        e.Term,
+		Penalty:     e.Penalty,
+		Fields:      fields,
+		Relevance:   (*BM25Params)(e.Relevance),
+		Details:     details,
+	}
+}
+
 func loadDocumentsFromFile(file *multipart.FileHeader) (UploadDocumentsFileDump, error) {
 	f, err := file.Open()
 	defer func(f multipart.File) {
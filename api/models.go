@@ -1,7 +1,9 @@
 package api
 
 type Document struct {
-	Title    string `json:"title" xml:"title" index:"title" binding:"required" `
-	Url      string `json:"url" xml:"url" binding:"required"`
-	Abstract string `json:"abstract" xml:"abstract" index:"abstract" binding:"required"`
+	Title     string    `json:"title" xml:"title" index:"title,boost=2" binding:"required" `
+	Url       string    `json:"url" xml:"url" binding:"required"`
+	Abstract  string    `json:"abstract" xml:"abstract" index:"abstract" binding:"required"`
+	Language  string    `json:"lang,omitempty" xml:"lang,omitempty" index:"lang,language"`
+	Embedding []float32 `json:"embedding,omitempty" xml:"-" index:"embedding,vector,dims=384"`
 }
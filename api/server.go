@@ -1,44 +1,81 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/micpst/minisearch/pkg/hnsw"
 	"github.com/micpst/minisearch/pkg/store"
 	"github.com/micpst/minisearch/pkg/tokenizer"
+	"github.com/micpst/minisearch/pkg/wal"
 )
 
+const shutdownTimeout = 10 * time.Second
+
 type Config struct {
 	DefaultLanguage tokenizer.Language
 	Port            uint
 	UploadLimit     int64
+	SnapshotPath    string
+	LogPath         string
+	LogConfig       *wal.Config
+	HNSW            *hnsw.Config
 }
 
 type Server struct {
 	config *Config
 	db     *store.MemDB[Document]
+	log    *wal.Log
 	router *gin.Engine
 }
 
-func New(c *Config) *Server {
+func New(c *Config) (*Server, error) {
+	log, err := openLog(c.LogPath, c.LogConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := loadSnapshot(c.SnapshotPath, &store.Config{
+		DefaultLanguage: c.DefaultLanguage,
+		TokenizerConfig: &tokenizer.Config{
+			EnableStemming:  true,
+			EnableStopWords: true,
+		},
+		Log:  log,
+		HNSW: c.HNSW,
+	})
+	if err == nil {
+		err = db.Replay()
+	}
+	if err != nil {
+		if log != nil {
+			_ = log.Close()
+		}
+		return nil, err
+	}
+
 	s := &Server{
 		config: c,
-		db: store.New[Document](&store.Config{
-			DefaultLanguage: c.DefaultLanguage,
-			TokenizerConfig: &tokenizer.Config{
-				EnableStemming:  true,
-				EnableStopWords: true,
-			},
-		}),
+		db:     db,
+		log:    log,
 		router: gin.Default(),
 	}
 	s.router.MaxMultipartMemory = s.config.UploadLimit
 	s.initRoutes()
-	return s
+	return s, nil
 }
 
 func (s *Server) initRoutes() {
 	s.router.POST("/api/v1/search", s.searchDocuments)
+	s.router.POST("/api/v1/suggest", s.suggest)
 	s.router.POST("/api/v1/upload", s.uploadDocuments)
 	s.router.POST("/api/v1/documents", s.createDocument)
 	s.router.PUT("/api/v1/documents/:id", s.updateDocument)
@@ -46,5 +83,95 @@ func (s *Server) initRoutes() {
 }
 
 func (s *Server) Run() error {
-	return s.router.Run(fmt.Sprintf(":%d", s.config.Port))
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", s.config.Port),
+		Handler: s.router,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-errs:
+		return err
+	case <-quit:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	if err := saveSnapshot(s.config.SnapshotPath, s.db); err != nil {
+		return err
+	}
+	if s.log != nil {
+		return s.log.Close()
+	}
+
+	return nil
+}
+
+func openLog(path string, c *wal.Config) (*wal.Log, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return wal.Open(path, c)
+}
+
+func loadSnapshot(path string, c *store.Config) (*store.MemDB[Document], error) {
+	if path == "" {
+		return store.New[Document](c)
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store.New[Document](c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func(f *os.File) {
+		_ = f.Close()
+	}(f)
+
+	return store.Load[Document](f, c)
+}
+
+func saveSnapshot(path string, db *store.MemDB[Document]) error {
+	if path == "" {
+		return nil
+	}
+
+	// write to a temporary file first so that a failed save never corrupts the previous snapshot
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func(name string) {
+		_ = os.Remove(name)
+	}(f.Name())
+
+	// the operation log is truncated only after the snapshot has been committed
+	err = db.Checkpoint(f, func() error {
+		if err := f.Sync(); err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		return os.Rename(f.Name(), path)
+	})
+	if err != nil {
+		_ = f.Close()
+	}
+
+	return err
 }
@@ -3,22 +3,47 @@ package main
 import (
 	"flag"
 	"log"
+	"time"
 
 	"github.com/micpst/minisearch/api"
+	"github.com/micpst/minisearch/pkg/hnsw"
 	"github.com/micpst/minisearch/pkg/tokenizer"
+	"github.com/micpst/minisearch/pkg/wal"
 )
 
 func main() {
 	lang := flag.String("l", string(tokenizer.ENGLISH), "Default language for the search engine")
 	port := flag.Uint("p", 3000, "Port for the server to listen on")
 	uploadLimit := flag.Int64("m", 8<<27, "Memory limit for file uploads (in bytes)")
+	snapshotPath := flag.String("s", "", "Path to the index snapshot loaded at boot and written on shutdown")
+	logPath := flag.String("w", "", "Path to the write-ahead log replayed at boot")
+	syncPolicy := flag.String("f", string(wal.SyncInterval), "Write-ahead log fsync policy (always, interval, never)")
+	syncInterval := flag.Duration("i", time.Second, "Write-ahead log fsync interval for the interval policy")
+	approximate := flag.Bool("a", false, "Index embeddings in an HNSW graph for approximate nearest-neighbour search")
 	flag.Parse()
 
-	s := api.New(&api.Config{
+	var graph *hnsw.Config
+	if *approximate {
+		graph = &hnsw.Config{}
+	}
+
+	s, err := api.New(&api.Config{
 		DefaultLanguage: tokenizer.Language(*lang),
 		Port:            *port,
 		UploadLimit:     *uploadLimit,
+		SnapshotPath:    *snapshotPath,
+		LogPath:         *logPath,
+		LogConfig: &wal.Config{
+			SyncPolicy:   wal.SyncPolicy(*syncPolicy),
+			SyncInterval: *syncInterval,
+		},
+		HNSW: graph,
 	})
-	err := s.Run()
-	log.Fatal(err)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := s.Run(); err != nil {
+		log.Fatal(err)
+	}
 }
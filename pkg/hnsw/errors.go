@@ -0,0 +1,11 @@
+package hnsw
+
+import "fmt"
+
+type InvalidSnapshotError struct {
+	Reason string
+}
+
+func (e *InvalidSnapshotError) Error() string {
+	return fmt.Sprintf("Invalid graph snapshot: %s", e.Reason)
+}
@@ -0,0 +1,326 @@
+package hnsw
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/micpst/minisearch/pkg/lib"
+)
+
+const (
+	DefaultM              = 16
+	DefaultEfConstruction = 200
+	DefaultEfSearch       = 50
+)
+
+type Key comparable
+
+// DistanceFunc measures how far apart two vectors are, closer vectors have smaller distances.
+type DistanceFunc func(a []float32, b []float32) float64
+
+// Config tunes the graph. M is the number of links of a node on the upper layers (doubled on the
+// bottom one), EfConstruction and EfSearch are the sizes of the candidate lists explored when
+// inserting and searching. Larger values give a better recall at the cost of speed and memory.
+type Config struct {
+	M              int
+	EfConstruction int
+	EfSearch       int
+	Distance       DistanceFunc
+	Seed           int64
+}
+
+type InsertParams[K Key] struct {
+	Id     K
+	Vector []float32
+}
+
+// SearchParams find the K nearest neighbours of Vector. Ef overrides the configured EfSearch and
+// Filter, unless nil, restricts the results without restricting the traversal of the graph.
+type SearchParams[K Key] struct {
+	Vector []float32
+	K      int
+	Ef     int
+	Filter func(K) bool
+}
+
+type SearchResult[K Key] struct {
+	Id       K
+	Distance float64
+}
+
+// Graph is a Hierarchical Navigable Small World graph for approximate nearest neighbour search.
+// It is not safe for concurrent use.
+type Graph[K Key] struct {
+	config          Config
+	nodes           map[K]*node[K]
+	entry           *node[K]
+	levelMultiplier float64
+	rng             *rand.Rand
+}
+
+func New[K Key](c Config) *Graph[K] {
+	if c.M <= 1 {
+		c.M = DefaultM
+	}
+	if c.EfConstruction <= 0 {
+		c.EfConstruction = DefaultEfConstruction
+	}
+	if c.EfSearch <= 0 {
+		c.EfSearch = DefaultEfSearch
+	}
+	if c.Distance == nil {
+		c.Distance = lib.EuclideanDistance
+	}
+
+	return &Graph[K]{
+		config:          c,
+		nodes:           make(map[K]*node[K]),
+		levelMultiplier: 1 / math.Log(float64(c.M)),
+		rng:             rand.New(rand.NewSource(c.Seed)),
+	}
+}
+
+func (g *Graph[K]) Len() int {
+	return len(g.nodes)
+}
+
+func (g *Graph[K]) Contains(id K) bool {
+	_, ok := g.nodes[id]
+	return ok
+}
+
+// Insert adds a vector to the graph, replacing the previous vector of the id.
+func (g *Graph[K]) Insert(params *InsertParams[K]) {
+	if g.Contains(params.Id) {
+		g.Delete(params.Id)
+	}
+
+	level := int(-math.Log(1-g.rng.Float64()) * g.levelMultiplier)
+	n := newNode(params.Id, params.Vector, level)
+	g.nodes[n.id] = n
+
+	if g.entry == nil {
+		g.entry = n
+		return
+	}
+
+	entries := []candidate[K]{{node: g.entry, distance: g.config.Distance(n.vector, g.entry.vector)}}
+	for l := g.entry.level(); l > level; l-- {
+		entries = g.searchLayer(n.vector, entries, 1, l, nil)
+	}
+
+	for l := min(level, g.entry.level()); l >= 0; l-- {
+		candidates := g.searchLayer(n.vector, entries, g.config.EfConstruction, l, nil)
+		for _, c := range g.selectNeighbours(candidates, g.config.M) {
+			n.neighbours[l] = append(n.neighbours[l], c.node.id)
+			g.connect(c.node, n, l)
+		}
+		entries = candidates
+	}
+
+	if level > g.entry.level() {
+		g.entry = n
+	}
+}
+
+// Delete removes the vector of the id and links its former neighbours with each other.
+func (g *Graph[K]) Delete(id K) {
+	n, ok := g.nodes[id]
+	if !ok {
+		return
+	}
+	delete(g.nodes, id)
+
+	for l, neighbours := range n.neighbours {
+		for _, neighbourId := range neighbours {
+			neighbour, ok := g.nodes[neighbourId]
+			if !ok || neighbour.level() < l {
+				continue
+			}
+			neighbour.removeNeighbour(l, id)
+			g.relink(neighbour, neighbours, l)
+		}
+	}
+
+	if g.entry == n {
+		g.entry = nil
+		for _, other := range g.nodes {
+			if g.entry == nil || other.level() > g.entry.level() {
+				g.entry = other
+			}
+		}
+	}
+}
+
+func (g *Graph[K]) Search(params *SearchParams[K]) []SearchResult[K] {
+	if g.entry == nil || params.K <= 0 {
+		return nil
+	}
+
+	ef := params.Ef
+	if ef <= 0 {
+		ef = g.config.EfSearch
+	}
+	ef = max(ef, params.K)
+
+	entries := []candidate[K]{{node: g.entry, distance: g.config.Distance(params.Vector, g.entry.vector)}}
+	for l := g.entry.level(); l > 0; l-- {
+		entries = g.searchLayer(params.Vector, entries, 1, l, nil)
+	}
+
+	candidates := g.searchLayer(params.Vector, entries, ef, 0, params.Filter)
+	if len(candidates) > params.K {
+		candidates = candidates[:params.K]
+	}
+
+	results := make([]SearchResult[K], len(candidates))
+	for i, c := range candidates {
+		results[i] = SearchResult[K]{Id: c.node.id, Distance: c.distance}
+	}
+
+	return results
+}
+
+// searchLayer returns up to ef nodes of the layer closest to the query, ordered by distance.
+// Links to deleted nodes, which are only removed from the known neighbours, are skipped.
+func (g *Graph[K]) searchLayer(query []float32, entries []candidate[K], ef int, level int, filter func(K) bool) []candidate[K] {
+	visited := make(map[K]struct{}, ef*g.config.M)
+	candidates := &candidateHeap[K]{}
+	results := &candidateHeap[K]{max: true}
+
+	for _, entry := range entries {
+		visited[entry.node.id] = struct{}{}
+		heap.Push(candidates, entry)
+		if filter == nil || filter(entry.node.id) {
+			heap.Push(results, entry)
+			if results.Len() > ef {
+				heap.Pop(results)
+			}
+		}
+	}
+
+	for candidates.Len() > 0 {
+		current := heap.Pop(candidates).(candidate[K])
+		if results.Len() >= ef && current.distance > results.top().distance {
+			break
+		}
+
+		for _, id := range current.node.neighbours[level] {
+			if _, ok := visited[id]; ok {
+				continue
+			}
+			visited[id] = struct{}{}
+
+			n, ok := g.nodes[id]
+			if !ok || n.level() < level {
+				continue
+			}
+
+			distance := g.config.Distance(query, n.vector)
+			if results.Len() < ef || distance < results.top().distance {
+				c := candidate[K]{node: n, distance: distance}
+				heap.Push(candidates, c)
+				if filter == nil || filter(id) {
+					heap.Push(results, c)
+					if results.Len() > ef {
+						heap.Pop(results)
+					}
+				}
+			}
+		}
+	}
+
+	sorted := make([]candidate[K], results.Len())
+	for i := len(sorted) - 1; i >= 0; i-- {
+		sorted[i] = heap.Pop(results).(candidate[K])
+	}
+
+	return sorted
+}
+
+// selectNeighbours picks up to m of the candidates, ordered by distance, preferring the ones closer
+// to the node than to any already selected neighbour so that the links spread in all directions.
+func (g *Graph[K]) selectNeighbours(candidates []candidate[K], m int) []candidate[K] {
+	if len(candidates) <= m {
+		return candidates
+	}
+
+	selected := make([]candidate[K], 0, m)
+	pruned := make([]candidate[K], 0, len(candidates))
+	for _, c := range candidates {
+		if len(selected) == m {
+			break
+		}
+
+		diverse := true
+		for _, s := range selected {
+			if g.config.Distance(c.node.vector, s.node.vector) < c.distance {
+				diverse = false
+				break
+			}
+		}
+
+		if diverse {
+			selected = append(selected, c)
+		} else {
+			pruned = append(pruned, c)
+		}
+	}
+
+	for _, c := range pruned {
+		if len(selected) == m {
+			break
+		}
+		selected = append(selected, c)
+	}
+
+	return selected
+}
+
+func (g *Graph[K]) maxNeighbours(level int) int {
+	if level == 0 {
+		return 2 * g.config.M
+	}
+	return g.config.M
+}
+
+// connect links the neighbour back to the new node, dropping its worst links when it has too many.
+func (g *Graph[K]) connect(neighbour *node[K], n *node[K], level int) {
+	neighbour.neighbours[level] = append(neighbour.neighbours[level], n.id)
+	if len(neighbour.neighbours[level]) > g.maxNeighbours(level) {
+		g.relink(neighbour, nil, level)
+	}
+}
+
+// relink chooses the best links of a node among its current neighbours and the extra candidates.
+func (g *Graph[K]) relink(n *node[K], extra []K, level int) {
+	seen := map[K]struct{}{n.id: {}}
+	candidates := make([]candidate[K], 0, len(n.neighbours[level])+len(extra))
+
+	for _, ids := range [][]K{n.neighbours[level], extra} {
+		for _, id := range ids {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+
+			other, ok := g.nodes[id]
+			if !ok || other.level() < level {
+				continue
+			}
+			candidates = append(candidates, candidate[K]{node: other, distance: g.config.Distance(n.vector, other.vector)})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	selected := g.selectNeighbours(candidates, g.maxNeighbours(level))
+	n.neighbours[level] = make([]K, len(selected))
+	for i, c := range selected {
+		n.neighbours[level][i] = c.node.id
+	}
+}
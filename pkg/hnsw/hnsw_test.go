@@ -0,0 +1,233 @@
+package hnsw
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/micpst/minisearch/pkg/lib"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestCase[Given any, Expected any] struct {
+	given    Given
+	expected Expected
+}
+
+type GraphInput struct {
+	config  Config
+	vectors int
+	dims    int
+}
+
+func randomVectors(n int, dims int, seed int64) [][]float32 {
+	rng := rand.New(rand.NewSource(seed))
+	vectors := make([][]float32, n)
+	for i := range vectors {
+		vectors[i] = make([]float32, dims)
+		for j := range vectors[i] {
+			vectors[i][j] = rng.Float32()
+		}
+	}
+	return vectors
+}
+
+func buildGraph(config Config, vectors [][]float32) *Graph[int] {
+	g := New[int](config)
+	for i, vector := range vectors {
+		g.Insert(&InsertParams[int]{Id: i, Vector: vector})
+	}
+	return g
+}
+
+// exactSearch is the brute-force scan the graph is compared against.
+func exactSearch(vectors map[int][]float32, query []float32, k int) []int {
+	ids := make([]int, 0, len(vectors))
+	distances := make(map[int]float64, len(vectors))
+	for id, vector := range vectors {
+		ids = append(ids, id)
+		distances[id] = lib.EuclideanDistance(query, vector)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return distances[ids[i]] < distances[ids[j]]
+	})
+	if len(ids) > k {
+		ids = ids[:k]
+	}
+	return ids
+}
+
+func recall(results []SearchResult[int], expected []int) float64 {
+	found := 0
+	for _, result := range results {
+		for _, id := range expected {
+			if result.Id == id {
+				found++
+				break
+			}
+		}
+	}
+	return float64(found) / float64(len(expected))
+}
+
+func vectorMap(vectors [][]float32) map[int][]float32 {
+	m := make(map[int][]float32, len(vectors))
+	for i, vector := range vectors {
+		m[i] = vector
+	}
+	return m
+}
+
+func TestSearch(t *testing.T) {
+	cases := []TestCase[GraphInput, float64]{
+		{
+			given:    GraphInput{config: Config{M: 8, EfConstruction: 100}, vectors: 1000, dims: 8},
+			expected: 0.9,
+		},
+		{
+			given:    GraphInput{config: Config{M: 16, EfConstruction: 200, EfSearch: 100}, vectors: 2000, dims: 16},
+			expected: 0.9,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("M=%d", c.given.config.M), func(t *testing.T) {
+			vectors := randomVectors(c.given.vectors, c.given.dims, 1)
+			g := buildGraph(c.given.config, vectors)
+			all := vectorMap(vectors)
+
+			total := 0.0
+			queries := randomVectors(50, c.given.dims, 2)
+			for _, query := range queries {
+				results := g.Search(&SearchParams[int]{Vector: query, K: 10})
+				assert.Len(t, results, 10)
+				assert.True(t, sort.SliceIsSorted(results, func(i, j int) bool {
+					return results[i].Distance < results[j].Distance
+				}))
+				total += recall(results, exactSearch(all, query, 10))
+			}
+
+			assert.Equal(t, c.given.vectors, g.Len())
+			assert.GreaterOrEqual(t, total/float64(len(queries)), c.expected)
+		})
+	}
+}
+
+func TestSearchFilter(t *testing.T) {
+	vectors := randomVectors(500, 4, 1)
+	g := buildGraph(Config{}, vectors)
+
+	results := g.Search(&SearchParams[int]{
+		Vector: vectors[10],
+		K:      5,
+		Filter: func(id int) bool { return id%2 == 0 },
+	})
+
+	assert.Len(t, results, 5)
+	assert.Equal(t, 10, results[0].Id)
+	for _, result := range results {
+		assert.Zero(t, result.Id%2)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	vectors := randomVectors(1000, 8, 1)
+	g := buildGraph(Config{M: 8}, vectors)
+	all := vectorMap(vectors)
+
+	for id := 0; id < len(vectors); id += 2 {
+		g.Delete(id)
+		delete(all, id)
+	}
+	g.Delete(len(vectors))
+
+	assert.Equal(t, len(all), g.Len())
+
+	total := 0.0
+	queries := randomVectors(50, 8, 2)
+	for _, query := range queries {
+		results := g.Search(&SearchParams[int]{Vector: query, K: 10})
+		for _, result := range results {
+			assert.Contains(t, all, result.Id)
+		}
+		total += recall(results, exactSearch(all, query, 10))
+	}
+	assert.GreaterOrEqual(t, total/float64(len(queries)), 0.9)
+
+	for id := range all {
+		g.Delete(id)
+	}
+
+	assert.Zero(t, g.Len())
+	assert.Empty(t, g.Search(&SearchParams[int]{Vector: queries[0], K: 10}))
+}
+
+func TestInsertReplace(t *testing.T) {
+	vectors := randomVectors(200, 4, 1)
+	g := buildGraph(Config{}, vectors)
+
+	g.Insert(&InsertParams[int]{Id: 0, Vector: []float32{10, 10, 10, 10}})
+	results := g.Search(&SearchParams[int]{Vector: []float32{10, 10, 10, 10}, K: 1})
+
+	assert.Equal(t, len(vectors), g.Len())
+	assert.Equal(t, []SearchResult[int]{{Id: 0, Distance: 0}}, results)
+}
+
+func TestSnapshot(t *testing.T) {
+	vectors := randomVectors(500, 8, 1)
+	g := buildGraph(Config{}, vectors)
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(g.Snapshot()))
+
+	snap := &Snapshot[int]{}
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(snap))
+
+	restored := New[int](Config{})
+	assert.NoError(t, restored.Restore(snap))
+	assert.Equal(t, g.Len(), restored.Len())
+
+	for _, query := range randomVectors(20, 8, 2) {
+		params := &SearchParams[int]{Vector: query, K: 10}
+		assert.Equal(t, g.Search(params), restored.Search(params))
+	}
+
+	assert.Error(t, restored.Restore(&Snapshot[int]{Entry: 1, Nodes: []NodeSnapshot[int]{{Id: 0, Neighbours: [][]int{nil}}}}))
+	assert.Error(t, restored.Restore(&Snapshot[int]{Nodes: []NodeSnapshot[int]{{Id: 0}}}))
+	assert.NoError(t, restored.Restore(&Snapshot[int]{}))
+	assert.Zero(t, restored.Len())
+}
+
+// BenchmarkSearch compares the latency of the graph search with an exact scan and reports its recall@10.
+func BenchmarkSearch(b *testing.B) {
+	vectors := randomVectors(10000, 64, 1)
+	queries := randomVectors(100, 64, 2)
+	all := vectorMap(vectors)
+
+	expected := make([][]int, len(queries))
+	for i, query := range queries {
+		expected[i] = exactSearch(all, query, 10)
+	}
+
+	b.Run("exact", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			exactSearch(all, queries[i%len(queries)], 10)
+		}
+	})
+
+	g := buildGraph(Config{}, vectors)
+	for _, ef := range []int{10, 50, 100, 200} {
+		b.Run(fmt.Sprintf("ef=%d", ef), func(b *testing.B) {
+			total := 0.0
+			for i := 0; i < b.N; i++ {
+				q := i % len(queries)
+				results := g.Search(&SearchParams[int]{Vector: queries[q], K: 10, Ef: ef})
+				total += recall(results, expected[q])
+			}
+			b.ReportMetric(total/float64(b.N), "recall")
+		})
+	}
+}
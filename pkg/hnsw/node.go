@@ -0,0 +1,63 @@
+package hnsw
+
+type node[K Key] struct {
+	id         K
+	vector     []float32
+	neighbours [][]K
+}
+
+func newNode[K Key](id K, vector []float32, level int) *node[K] {
+	return &node[K]{
+		id:         id,
+		vector:     vector,
+		neighbours: make([][]K, level+1),
+	}
+}
+
+func (n *node[K]) level() int {
+	return len(n.neighbours) - 1
+}
+
+func (n *node[K]) removeNeighbour(level int, id K) {
+	neighbours := n.neighbours[level]
+	for i, neighbour := range neighbours {
+		if neighbour == id {
+			n.neighbours[level] = append(neighbours[:i], neighbours[i+1:]...)
+			return
+		}
+	}
+}
+
+type candidate[K Key] struct {
+	node     *node[K]
+	distance float64
+}
+
+// candidateHeap is a binary heap of candidates, closest first unless it is a max heap.
+type candidateHeap[K Key] struct {
+	items []candidate[K]
+	max   bool
+}
+
+func (h *candidateHeap[K]) Len() int { return len(h.items) }
+
+func (h *candidateHeap[K]) Less(i, j int) bool {
+	if h.max {
+		return h.items[i].distance > h.items[j].distance
+	}
+	return h.items[i].distance < h.items[j].distance
+}
+
+func (h *candidateHeap[K]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *candidateHeap[K]) Push(x any) { h.items = append(h.items, x.(candidate[K])) }
+
+func (h *candidateHeap[K]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+func (h *candidateHeap[K]) top() candidate[K] {
+	return h.items[0]
+}
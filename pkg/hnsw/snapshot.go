@@ -0,0 +1,57 @@
+package hnsw
+
+// Snapshot is the encodable form of a graph, including the vectors and the links of every node.
+type Snapshot[K Key] struct {
+	Entry K
+	Nodes []NodeSnapshot[K]
+}
+
+type NodeSnapshot[K Key] struct {
+	Id         K
+	Vector     []float32
+	Neighbours [][]K
+}
+
+func (g *Graph[K]) Snapshot() *Snapshot[K] {
+	snap := &Snapshot[K]{Nodes: make([]NodeSnapshot[K], 0, len(g.nodes))}
+	if g.entry != nil {
+		snap.Entry = g.entry.id
+	}
+
+	for _, n := range g.nodes {
+		snap.Nodes = append(snap.Nodes, NodeSnapshot[K]{
+			Id:         n.id,
+			Vector:     n.vector,
+			Neighbours: n.neighbours,
+		})
+	}
+
+	return snap
+}
+
+// Restore replaces the content of the graph with the snapshot.
+func (g *Graph[K]) Restore(snap *Snapshot[K]) error {
+	nodes := make(map[K]*node[K], len(snap.Nodes))
+	for _, n := range snap.Nodes {
+		if len(n.Neighbours) == 0 {
+			return &InvalidSnapshotError{Reason: "node without layers"}
+		}
+		if _, ok := nodes[n.Id]; ok {
+			return &InvalidSnapshotError{Reason: "duplicated node"}
+		}
+		nodes[n.Id] = &node[K]{id: n.Id, vector: n.Vector, neighbours: n.Neighbours}
+	}
+
+	var entry *node[K]
+	if len(nodes) > 0 {
+		var ok bool
+		if entry, ok = nodes[snap.Entry]; !ok {
+			return &InvalidSnapshotError{Reason: "unknown entry point"}
+		}
+	}
+
+	g.nodes = nodes
+	g.entry = entry
+
+	return nil
+}
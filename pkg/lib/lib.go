@@ -2,6 +2,7 @@ package lib
 
 import (
 	"math"
+	"sort"
 )
 
 func Count(tokens []string) map[string]int {
@@ -17,6 +18,64 @@ func BM25(tf float64, matchingDocsCount int, fieldLength int, avgFieldLength flo
 	return idf * (d + tf*(k+1)) / (tf + k*(1-b+(b*float64(fieldLength))/avgFieldLength))
 }
 
+func TFIDF(tf float64, matchingDocsCount int, docsCount int) float64 {
+	idf := 1 + math.Log(float64(1+docsCount)/float64(1+matchingDocsCount))
+	return tf * idf
+}
+
+// PhraseFrequency counts the in-order occurrences of a phrase given the sorted positions of each of its terms.
+// Every occurrence spanning at most slop extra positions contributes 1/(1+distance), so adjacent terms count the most.
+func PhraseFrequency(positions [][]int, slop int) float64 {
+	if len(positions) == 0 {
+		return 0
+	}
+
+	frequency := 0.0
+
+	for _, start := range positions[0] {
+		prev := start
+		for _, termPositions := range positions[1:] {
+			i := sort.SearchInts(termPositions, prev+1)
+			if i == len(termPositions) {
+				return frequency
+			}
+			prev = termPositions[i]
+		}
+
+		if distance := prev - start - (len(positions) - 1); distance <= slop {
+			frequency += 1 / float64(1+distance)
+		}
+	}
+
+	return frequency
+}
+
+func DotProduct(a []float32, b []float32) float64 {
+	product := 0.0
+	for i := range a {
+		product += float64(a[i]) * float64(b[i])
+	}
+	return product
+}
+
+// CosineSimilarity returns 0 if any of the vectors has no magnitude.
+func CosineSimilarity(a []float32, b []float32) float64 {
+	magnitude := math.Sqrt(DotProduct(a, a) * DotProduct(b, b))
+	if magnitude == 0 {
+		return 0
+	}
+	return DotProduct(a, b) / magnitude
+}
+
+func EuclideanDistance(a []float32, b []float32) float64 {
+	distance := 0.0
+	for i := range a {
+		diff := float64(a[i]) - float64(b[i])
+		distance += diff * diff
+	}
+	return math.Sqrt(distance)
+}
+
 func Paginate(offset int, limit int, sliceLength int) (int, int) {
 	if offset > sliceLength {
 		offset = sliceLength
@@ -46,6 +105,21 @@ func CommonPrefix(a []rune, b []rune) ([]rune, bool) {
 	return commonPrefix, equal
 }
 
+// NextLevenshteinRow extends the edit distance row of a word against every prefix of the term
+// with one more character of the word.
+func NextLevenshteinRow(row []int, char rune, term []rune) []int {
+	next := make([]int, len(row))
+	next[0] = row[0] + 1
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if term[i-1] == char {
+			cost = 0
+		}
+		next[i] = min(row[i]+1, next[i-1]+1, row[i-1]+cost)
+	}
+	return next
+}
+
 func BoundedLevenshtein(a []rune, b []rune, tolerance int) (int, bool) {
 	distance := boundedLevenshtein(a, b, tolerance)
 	return distance, distance >= 0
@@ -2,6 +2,7 @@ package lib
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,6 +24,11 @@ type BM25Input struct {
 	d                      float64
 }
 
+type VectorsInput struct {
+	a []float32
+	b []float32
+}
+
 type BoundedLevenshteinInput struct {
 	a         []rune
 	b         []rune
@@ -44,6 +50,17 @@ type CommonPrefixOutput struct {
 	equal        bool
 }
 
+type TFIDFInput struct {
+	termFrequency          float64
+	matchingDocumentsCount int
+	documentsCount         int
+}
+
+type PhraseFrequencyInput struct {
+	positions [][]int
+	slop      int
+}
+
 type PaginateInput struct {
 	offset int
 	limit  int
@@ -137,6 +154,120 @@ func TestBM25(t *testing.T) {
 	}
 }
 
+func TestTFIDF(t *testing.T) {
+	cases := []TestCase[TFIDFInput, float64]{
+		{
+			given: TFIDFInput{
+				termFrequency:          0.5,
+				matchingDocumentsCount: 9,
+				documentsCount:         9,
+			},
+			expected: 0.5,
+		},
+		{
+			given: TFIDFInput{
+				termFrequency:          0.25,
+				matchingDocumentsCount: 4,
+				documentsCount:         9,
+			},
+			expected: 0.25 * (1 + math.Ln2),
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			actual := TFIDF(c.given.termFrequency, c.given.matchingDocumentsCount, c.given.documentsCount)
+			assert.InDelta(t, c.expected, actual, 1e-9)
+		})
+	}
+}
+
+func TestPhraseFrequency(t *testing.T) {
+	cases := []TestCase[PhraseFrequencyInput, float64]{
+		{
+			given: PhraseFrequencyInput{
+				positions: [][]int{},
+				slop:      0,
+			},
+			expected: 0,
+		},
+		{
+			given: PhraseFrequencyInput{
+				positions: [][]int{{1, 7}, {2, 9}},
+				slop:      0,
+			},
+			expected: 1,
+		},
+		{
+			given: PhraseFrequencyInput{
+				positions: [][]int{{1, 7}, {2, 9}},
+				slop:      1,
+			},
+			expected: 1.5,
+		},
+		{
+			given: PhraseFrequencyInput{
+				positions: [][]int{{3}, {1}},
+				slop:      5,
+			},
+			expected: 0,
+		},
+		{
+			given: PhraseFrequencyInput{
+				positions: [][]int{{0, 2}, {1}, {0, 2}},
+				slop:      0,
+			},
+			expected: 1,
+		},
+		{
+			given: PhraseFrequencyInput{
+				positions: [][]int{{0}, {4}, {5}},
+				slop:      3,
+			},
+			expected: 0.25,
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			actual := PhraseFrequency(c.given.positions, c.given.slop)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestVectorSimilarity(t *testing.T) {
+	type Similarities struct {
+		dot       float64
+		cosine    float64
+		euclidean float64
+	}
+
+	cases := []TestCase[VectorsInput, Similarities]{
+		{
+			given:    VectorsInput{a: []float32{1, 0}, b: []float32{1, 0}},
+			expected: Similarities{dot: 1, cosine: 1, euclidean: 0},
+		},
+		{
+			given:    VectorsInput{a: []float32{1, 0}, b: []float32{0, 2}},
+			expected: Similarities{dot: 0, cosine: 0, euclidean: math.Sqrt(5)},
+		},
+		{
+			given:    VectorsInput{a: []float32{1, 2, 3}, b: []float32{-2, -4, -6}},
+			expected: Similarities{dot: -28, cosine: -1, euclidean: math.Sqrt(126)},
+		},
+		{
+			given:    VectorsInput{a: []float32{0, 0}, b: []float32{3, 4}},
+			expected: Similarities{dot: 0, cosine: 0, euclidean: 5},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			assert.InDelta(t, c.expected.dot, DotProduct(c.given.a, c.given.b), 1e-9)
+			assert.InDelta(t, c.expected.cosine, CosineSimilarity(c.given.a, c.given.b), 1e-9)
+			assert.InDelta(t, c.expected.euclidean, EuclideanDistance(c.given.a, c.given.b), 1e-9)
+		})
+	}
+}
+
 func TestPaginate(t *testing.T) {
 	cases := []TestCase[PaginateInput, PaginateOutput]{
 		{
@@ -303,3 +434,33 @@ func TestBoundedLevenshtein(t *testing.T) {
 		})
 	}
 }
+
+func TestNextLevenshteinRow(t *testing.T) {
+	cases := []TestCase[CommonPrefixInput, []int]{
+		{
+			given:    CommonPrefixInput{a: []rune(""), b: []rune("kit")},
+			expected: []int{0, 1, 2, 3},
+		},
+		{
+			given:    CommonPrefixInput{a: []rune("sit"), b: []rune("kit")},
+			expected: []int{3, 3, 2, 1},
+		},
+		{
+			given:    CommonPrefixInput{a: []rune("sitting"), b: []rune("kitten")},
+			expected: []int{7, 7, 6, 5, 4, 4, 3},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			row := make([]int, len(c.given.b)+1)
+			for i := range row {
+				row[i] = i
+			}
+			for _, char := range c.given.a {
+				row = NextLevenshteinRow(row, char, c.given.b)
+			}
+
+			assert.Equal(t, c.expected, row)
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package radix
 
 import (
-	"maps"
+	"slices"
 
 	"github.com/micpst/minisearch/pkg/lib"
 )
@@ -40,8 +40,8 @@ func (n *node[K, V]) removeData(id K) {
 	delete(n.data, id)
 }
 
-func (n *node[K, V]) findData(word []rune, term []rune, tolerance int, exact bool) map[K]V {
-	results := make(map[K]V)
+func (n *node[K, V]) findData(word []rune, term []rune, tolerance int, exact bool) []FindResult[K, V] {
+	results := make([]FindResult[K, V], 0)
 	stack := [][2]interface{}{{n, word}}
 
 	for len(stack) > 0 {
@@ -52,22 +52,88 @@ func (n *node[K, V]) findData(word []rune, term []rune, tolerance int, exact boo
 			break
 		}
 
-		if tolerance > 0 {
-			if _, isBounded := lib.BoundedLevenshtein(currWord, term, tolerance); isBounded {
-				maps.Copy(results, currNode.data)
+		if len(currNode.data) > 0 {
+			if tolerance > 0 {
+				if distance, isBounded := lib.BoundedLevenshtein(currWord, term, tolerance); isBounded {
+					results = append(results, FindResult[K, V]{Term: string(currWord), Distance: distance, Data: currNode.data})
+				}
+			} else {
+				results = append(results, FindResult[K, V]{Term: string(currWord), Data: currNode.data})
 			}
-		} else {
-			maps.Copy(results, currNode.data)
 		}
 
 		for _, child := range currNode.children {
-			stack = append(stack, [2]interface{}{child, append(currWord, child.subword...)})
+			// copy the word so that the siblings do not share the same backing array
+			childWord := append(currWord[:len(currWord):len(currWord)], child.subword...)
+			stack = append(stack, [2]interface{}{child, childWord})
 		}
 	}
 
 	return results
 }
 
+// findPrefix walks the subtree computing the edit distance rows of the words against the prefix,
+// row is nil once the best distance can no longer improve.
+func (n *node[K, V]) findPrefix(prefix []rune, row []int, best int, term []rune, tolerance int, results *[]FindResult[K, V]) {
+	word := append(prefix[:len(prefix):len(prefix)], n.subword...)
+
+	for _, char := range n.subword {
+		if row == nil {
+			break
+		}
+		row = lib.NextLevenshteinRow(row, char, term)
+		best = min(best, row[len(row)-1])
+
+		if slices.Min(row) > tolerance {
+			if best > tolerance {
+				return
+			}
+			row = nil
+		}
+	}
+
+	if best <= tolerance && len(n.data) > 0 {
+		*results = append(*results, FindResult[K, V]{Term: string(word), Distance: best, Data: n.data})
+	}
+
+	for _, child := range n.children {
+		child.findPrefix(word, row, best, term, tolerance, results)
+	}
+}
+
+// findSimilar walks the subtree computing the edit distance rows of the words against the term,
+// a subtree is skipped once none of its words can be within the tolerance.
+func (n *node[K, V]) findSimilar(prefix []rune, row []int, term []rune, tolerance int, results *[]FindResult[K, V]) {
+	word := append(prefix[:len(prefix):len(prefix)], n.subword...)
+
+	for _, char := range n.subword {
+		row = lib.NextLevenshteinRow(row, char, term)
+		if slices.Min(row) > tolerance {
+			return
+		}
+	}
+
+	if distance := row[len(row)-1]; distance <= tolerance && len(n.data) > 0 {
+		*results = append(*results, FindResult[K, V]{Term: string(word), Distance: distance, Data: n.data})
+	}
+
+	for _, child := range n.children {
+		child.findSimilar(word, row, term, tolerance, results)
+	}
+}
+
+func (n *node[K, V]) walk(prefix []rune, fn func(string, map[K]V)) {
+	word := append(prefix[:len(prefix):len(prefix)], n.subword...)
+
+	if len(n.data) > 0 {
+		fn(string(word), n.data)
+	}
+
+	for _, child := range n.children {
+		child.walk(word, fn)
+	}
+}
+
 func (n *node[K, V]) mergeNode(other *node[K, V]) {
 	n.subword = append(n.subword, other.subword...)
 	n.data = other.data
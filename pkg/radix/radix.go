@@ -24,9 +24,18 @@ type FindParams struct {
 	Exact     bool
 }
 
-type FindResult[V Value] struct {
-	Id   string
-	Data V
+// PrefixParams find the words starting with Prefix, or with a prefix within Tolerance edits of it.
+type PrefixParams struct {
+	Prefix    string
+	Tolerance int
+}
+
+// FindResult holds the records of a single indexed word matching the searched term.
+// Data is shared with the trie and must not be modified.
+type FindResult[K Key, V Value] struct {
+	Term     string
+	Distance int
+	Data     map[K]V
 }
 
 type Trie[K Key, V Value] struct {
@@ -148,7 +157,7 @@ func (t *Trie[K, V]) Delete(params *DeleteParams[K]) {
 	}
 }
 
-func (t *Trie[K, V]) Find(params *FindParams) map[K]V {
+func (t *Trie[K, V]) Find(params *FindParams) []FindResult[K, V] {
 	term := []rune(params.Term)
 	currNode := t.root
 	currNodeWord := currNode.subword
@@ -169,7 +178,7 @@ func (t *Trie[K, V]) Find(params *FindParams) map[K]V {
 				if params.Tolerance > 0 {
 					break
 				}
-				return map[K]V{}
+				return []FindResult[K, V]{}
 			}
 
 			// skip to the next divergent character
@@ -181,10 +190,45 @@ func (t *Trie[K, V]) Find(params *FindParams) map[K]V {
 			// update the current node word
 			currNodeWord = append(currNodeWord, currChild.subword...)
 		} else {
-			// if the node for the curr character doesn't exist abort the deletion
-			return map[K]V{}
+			// if the node for the curr character doesn't exist abort the search
+			return []FindResult[K, V]{}
 		}
 	}
 
 	return currNode.findData(currNodeWord, term, params.Tolerance, params.Exact)
 }
+
+// FindPrefix returns the words completing the prefix. The distance of a word is the smallest
+// edit distance between the prefix and any prefix of the word. The tolerance is kept below the
+// length of the prefix, otherwise every word would complete it.
+func (t *Trie[K, V]) FindPrefix(params *PrefixParams) []FindResult[K, V] {
+	prefix := []rune(params.Prefix)
+	tolerance := min(params.Tolerance, max(len(prefix)-1, 0))
+	row := make([]int, len(prefix)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	results := make([]FindResult[K, V], 0)
+	t.root.findPrefix(nil, row, len(prefix), prefix, tolerance, &results)
+
+	return results
+}
+
+// FindSimilar returns the words within Tolerance edits of Term, wherever the edits are.
+func (t *Trie[K, V]) FindSimilar(params *FindParams) []FindResult[K, V] {
+	term := []rune(params.Term)
+	row := make([]int, len(term)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	results := make([]FindResult[K, V], 0)
+	t.root.findSimilar(nil, row, term, params.Tolerance, &results)
+
+	return results
+}
+
+func (t *Trie[K, V]) Walk(fn func(word string, data map[K]V)) {
+	t.root.walk(nil, fn)
+}
@@ -322,22 +322,27 @@ func TestDelete(t *testing.T) {
 }
 
 func TestFind(t *testing.T) {
-	cases := []TestCase[FindParams, map[string]RecordInfo]{
+	cases := []TestCase[FindParams, []FindResult[string, RecordInfo]]{
 		{
 			given: FindParams{
 				Term:      "what",
 				Tolerance: 0,
 				Exact:     false,
 			},
-			expected: map[string]RecordInfo{},
+			expected: []FindResult[string, RecordInfo]{},
 		},
 		{
 			given: FindParams{
 				Term:  "australia",
 				Exact: true,
 			},
-			expected: map[string]RecordInfo{
-				"998c8de6-3c50-4e9e-9835-10f8d1215327": {termFrequency: 1.29513358272291},
+			expected: []FindResult[string, RecordInfo]{
+				{
+					Term: "australia",
+					Data: map[string]RecordInfo{
+						"998c8de6-3c50-4e9e-9835-10f8d1215327": {termFrequency: 1.29513358272291},
+					},
+				},
 			},
 		},
 		{
@@ -346,9 +351,19 @@ func TestFind(t *testing.T) {
 				Tolerance: 0,
 				Exact:     false,
 			},
-			expected: map[string]RecordInfo{
-				"998c8de6-3c50-4e9e-9835-10f8d1215327": {termFrequency: 1.29513358272291},
-				"2e48c6df-bafa-4981-b61a-16879dcdde2a": {termFrequency: 3.64961844222847},
+			expected: []FindResult[string, RecordInfo]{
+				{
+					Term: "australia",
+					Data: map[string]RecordInfo{
+						"998c8de6-3c50-4e9e-9835-10f8d1215327": {termFrequency: 1.29513358272291},
+					},
+				},
+				{
+					Term: "australian",
+					Data: map[string]RecordInfo{
+						"2e48c6df-bafa-4981-b61a-16879dcdde2a": {termFrequency: 3.64961844222847},
+					},
+				},
 			},
 		},
 		{
@@ -357,8 +372,14 @@ func TestFind(t *testing.T) {
 				Tolerance: 2,
 				Exact:     false,
 			},
-			expected: map[string]RecordInfo{
-				"2e48c6df-bafa-4981-b61a-16879dcdde2a": {termFrequency: 3.64961844222847},
+			expected: []FindResult[string, RecordInfo]{
+				{
+					Term:     "australian",
+					Distance: 0,
+					Data: map[string]RecordInfo{
+						"2e48c6df-bafa-4981-b61a-16879dcdde2a": {termFrequency: 3.64961844222847},
+					},
+				},
 			},
 		},
 		{
@@ -367,8 +388,14 @@ func TestFind(t *testing.T) {
 				Tolerance: 3,
 				Exact:     false,
 			},
-			expected: map[string]RecordInfo{
-				"998c8de6-3c50-4e9e-9835-10f8d1215327": {termFrequency: 1.29513358272291},
+			expected: []FindResult[string, RecordInfo]{
+				{
+					Term:     "australia",
+					Distance: 3,
+					Data: map[string]RecordInfo{
+						"998c8de6-3c50-4e9e-9835-10f8d1215327": {termFrequency: 1.29513358272291},
+					},
+				},
 			},
 		},
 	}
@@ -393,7 +420,140 @@ func TestFind(t *testing.T) {
 
 			results := index.Find(&c.given)
 
-			assert.Equal(t, c.expected, results)
+			assert.ElementsMatch(t, c.expected, results)
+		})
+	}
+}
+
+func TestFindPrefix(t *testing.T) {
+	australian := map[string]RecordInfo{"2e48c6df-bafa-4981-b61a-16879dcdde2a": {termFrequency: 3.64961844222847}}
+	australia := map[string]RecordInfo{"998c8de6-3c50-4e9e-9835-10f8d1215327": {termFrequency: 1.29513358272291}}
+	austrian := map[string]RecordInfo{"1e44c6df-bafa-4981-b61a-16879d2dddghf": {termFrequency: 2.27923284424328}}
+
+	cases := []TestCase[PrefixParams, []FindResult[string, RecordInfo]]{
+		{
+			given:    PrefixParams{Prefix: "what"},
+			expected: []FindResult[string, RecordInfo]{},
+		},
+		{
+			given: PrefixParams{Prefix: "austral"},
+			expected: []FindResult[string, RecordInfo]{
+				{Term: "australia", Data: australia},
+				{Term: "australian", Data: australian},
+			},
+		},
+		{
+			given: PrefixParams{Prefix: ""},
+			expected: []FindResult[string, RecordInfo]{
+				{Term: "australia", Data: australia},
+				{Term: "australian", Data: australian},
+				{Term: "austrian", Data: austrian},
+			},
+		},
+		{
+			given: PrefixParams{Prefix: "ausri", Tolerance: 1},
+			expected: []FindResult[string, RecordInfo]{
+				{Term: "austrian", Distance: 1, Data: austrian},
+			},
+		},
+		{
+			given: PrefixParams{Prefix: "ostral", Tolerance: 2},
+			expected: []FindResult[string, RecordInfo]{
+				{Term: "australia", Distance: 2, Data: australia},
+				{Term: "australian", Distance: 2, Data: australian},
+			},
+		},
+		{
+			given:    PrefixParams{Prefix: "x", Tolerance: 1},
+			expected: []FindResult[string, RecordInfo]{},
+		},
+		{
+			given: PrefixParams{Prefix: "ua", Tolerance: 2},
+			expected: []FindResult[string, RecordInfo]{
+				{Term: "australia", Distance: 1, Data: australia},
+				{Term: "australian", Distance: 1, Data: australian},
+				{Term: "austrian", Distance: 1, Data: austrian},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			index := New[string, RecordInfo]()
+			index.Insert(&InsertParams[string, RecordInfo]{
+				Id:   "2e48c6df-bafa-4981-b61a-16879dcdde2a",
+				Word: "australian",
+				Data: RecordInfo{termFrequency: 3.64961844222847},
+			})
+			index.Insert(&InsertParams[string, RecordInfo]{
+				Id:   "998c8de6-3c50-4e9e-9835-10f8d1215327",
+				Word: "australia",
+				Data: RecordInfo{termFrequency: 1.29513358272291},
+			})
+			index.Insert(&InsertParams[string, RecordInfo]{
+				Id:   "1e44c6df-bafa-4981-b61a-16879d2dddghf",
+				Word: "austrian",
+				Data: RecordInfo{termFrequency: 2.27923284424328},
+			})
+
+			results := index.FindPrefix(&c.given)
+
+			assert.ElementsMatch(t, c.expected, results)
+		})
+	}
+}
+
+func TestFindSimilar(t *testing.T) {
+	australian := map[string]RecordInfo{"2e48c6df-bafa-4981-b61a-16879dcdde2a": {termFrequency: 3.64961844222847}}
+	australia := map[string]RecordInfo{"998c8de6-3c50-4e9e-9835-10f8d1215327": {termFrequency: 1.29513358272291}}
+	austrian := map[string]RecordInfo{"1e44c6df-bafa-4981-b61a-16879d2dddghf": {termFrequency: 2.27923284424328}}
+
+	cases := []TestCase[FindParams, []FindResult[string, RecordInfo]]{
+		{
+			given:    FindParams{Term: "austria"},
+			expected: []FindResult[string, RecordInfo]{},
+		},
+		{
+			given: FindParams{Term: "australia"},
+			expected: []FindResult[string, RecordInfo]{
+				{Term: "australia", Data: australia},
+			},
+		},
+		{
+			given: FindParams{Term: "australiaa", Tolerance: 1},
+			expected: []FindResult[string, RecordInfo]{
+				{Term: "australia", Distance: 1, Data: australia},
+				{Term: "australian", Distance: 1, Data: australian},
+			},
+		},
+		{
+			given: FindParams{Term: "oustrian", Tolerance: 2},
+			expected: []FindResult[string, RecordInfo]{
+				{Term: "austrian", Distance: 1, Data: austrian},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			index := New[string, RecordInfo]()
+			index.Insert(&InsertParams[string, RecordInfo]{
+				Id:   "2e48c6df-bafa-4981-b61a-16879dcdde2a",
+				Word: "australian",
+				Data: RecordInfo{termFrequency: 3.64961844222847},
+			})
+			index.Insert(&InsertParams[string, RecordInfo]{
+				Id:   "998c8de6-3c50-4e9e-9835-10f8d1215327",
+				Word: "australia",
+				Data: RecordInfo{termFrequency: 1.29513358272291},
+			})
+			index.Insert(&InsertParams[string, RecordInfo]{
+				Id:   "1e44c6df-bafa-4981-b61a-16879d2dddghf",
+				Word: "austrian",
+				Data: RecordInfo{termFrequency: 2.27923284424328},
+			})
+
+			results := index.FindSimilar(&c.given)
+
+			assert.ElementsMatch(t, c.expected, results)
 		})
 	}
 }
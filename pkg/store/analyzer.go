@@ -0,0 +1,127 @@
+package store
+
+import (
+	"github.com/micpst/minisearch/pkg/tokenizer"
+)
+
+const KEYWORD = "keyword"
+
+var builtinAnalyzers = map[string]*tokenizer.Analyzer{
+	KEYWORD: {
+		CharFilters: []tokenizer.CharFilter{tokenizer.Lowercase},
+		Tokenizer:   &tokenizer.KeywordTokenizer{},
+	},
+}
+
+// emptyAnalyzer analyzes the texts in an unsupported language, which cannot be split into tokens.
+var emptyAnalyzer = &tokenizer.Analyzer{Tokenizer: emptyTokenizer{}}
+
+type emptyTokenizer struct{}
+
+func (emptyTokenizer) Split(string) [][2]int {
+	return nil
+}
+
+// isSupportedAnalyzer reports whether the name is empty, a registered analyzer or a supported language.
+func isSupportedAnalyzer(name string, analyzers map[string]*tokenizer.Analyzer) bool {
+	if _, ok := analyzers[name]; ok || name == "" {
+		return true
+	}
+	return tokenizer.IsSupportedLanguage(tokenizer.Language(name))
+}
+
+// propertyAnalysis is the effective analysis of a text property. Analyzer is the name of its analyzer, empty
+// for the built-in analyzer of the document language, and Config the tokenizer config of the built-in analyzers.
+type propertyAnalysis struct {
+	Analyzer string
+	Config   tokenizer.Config
+}
+
+// propertyAnalysis returns the analysis of a text property. The analyzer of the property tag takes precedence
+// over the default one, and the stemming and stop words of the tag override the tokenizer config.
+func (db *MemDB[S]) propertyAnalysis(property string) propertyAnalysis {
+	analysis := propertyAnalysis{Analyzer: db.defaultAnalyzer}
+	if db.tokenizerConfig != nil {
+		analysis.Config = *db.tokenizerConfig
+	}
+
+	if prop, ok := db.index.properties[property]; ok {
+		if prop.analyzer != "" {
+			analysis.Analyzer = prop.analyzer
+		}
+		if prop.stem != nil {
+			analysis.Config.EnableStemming = *prop.stem
+		}
+		if prop.stopWords != nil {
+			analysis.Config.EnableStopWords = *prop.stopWords
+		}
+	}
+
+	return analysis
+}
+
+type analyzerKey struct {
+	property string
+	language tokenizer.Language
+}
+
+// analyzerCache holds the analyzers of the text properties for every language at a revision of the languages.
+type analyzerCache struct {
+	revision  uint64
+	analyzers map[analyzerKey]*tokenizer.Analyzer
+}
+
+// newAnalyzerCache builds the analyzers of the text properties for all the supported languages.
+func (db *MemDB[S]) newAnalyzerCache() *analyzerCache {
+	// the revision is read first, so the languages registered meanwhile invalidate the cache
+	cache := &analyzerCache{revision: tokenizer.Revision()}
+	languages := tokenizer.Languages()
+	cache.analyzers = make(map[analyzerKey]*tokenizer.Analyzer, len(db.index.indexes)*len(languages))
+	for prop := range db.index.indexes {
+		for _, language := range languages {
+			if analyzer, err := db.newAnalyzer(prop, language); err == nil {
+				cache.analyzers[analyzerKey{property: prop, language: language}] = analyzer
+			}
+		}
+	}
+	return cache
+}
+
+// analyzer returns the analyzer of a text property for the documents in the given language.
+// The analyzers are rebuilt once a language is registered, and the texts in an unsupported language produce no tokens.
+func (db *MemDB[S]) analyzer(property string, language tokenizer.Language) *tokenizer.Analyzer {
+	cache := db.analyzerCache.Load()
+	if cache.revision != tokenizer.Revision() {
+		cache = db.newAnalyzerCache()
+		db.analyzerCache.Store(cache)
+	}
+	if analyzer, ok := cache.analyzers[analyzerKey{property: property, language: language}]; ok {
+		return analyzer
+	}
+	if analyzer, err := db.newAnalyzer(property, language); err == nil {
+		return analyzer
+	}
+	return emptyAnalyzer
+}
+
+// newAnalyzer builds the analyzer of a text property for the documents in the given language,
+// an analyzer named after a language selects its built-in analyzer.
+func (db *MemDB[S]) newAnalyzer(property string, language tokenizer.Language) (*tokenizer.Analyzer, error) {
+	analysis := db.propertyAnalysis(property)
+	if analyzer, ok := db.analyzers[analysis.Analyzer]; ok {
+		return analyzer, nil
+	}
+	if analysis.Analyzer != "" {
+		language = tokenizer.Language(analysis.Analyzer)
+	}
+	return tokenizer.NewAnalyzer(language, &analysis.Config)
+}
+
+// propertyAnalyzers returns the analyzers of all the text properties for the documents in the given language.
+func (db *MemDB[S]) propertyAnalyzers(language tokenizer.Language) map[string]*tokenizer.Analyzer {
+	analyzers := make(map[string]*tokenizer.Analyzer, len(db.index.indexes))
+	for prop := range db.index.indexes {
+		analyzers[prop] = db.analyzer(prop, language)
+	}
+	return analyzers
+}
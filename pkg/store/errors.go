@@ -14,6 +14,77 @@ type WrongSearchPropertyType struct {
 	Property string
 }
 
+type WrongFilterPropertyType struct {
+	Property string
+}
+
+type WrongFacetPropertyType struct {
+	Property string
+}
+
+type WrongSortPropertyType struct {
+	Property string
+}
+
+type SortOrderNotSupportedError struct {
+	Order SortOrder
+}
+
+type FusionNotSupportedError struct {
+	Fusion Fusion
+}
+
+type ScoringNotSupportedError struct {
+	Scoring Scoring
+}
+
+type AnalyzerNotSupportedError struct {
+	Analyzer string
+}
+
+type InvalidIndexTagError struct {
+	Field  string
+	Tag    string
+	Reason string
+}
+
+type UnsupportedFieldTypeError struct {
+	Field string
+	Type  string
+}
+
+type VectorDimensionError struct {
+	Property string
+	Expected int
+	Actual   int
+}
+
+type WrongVectorPropertyType struct {
+	Property string
+}
+
+type InvalidSearchParamsError struct {
+	Reason string
+}
+
+type QuerySyntaxError struct {
+	Query    string
+	Position int
+	Reason   string
+}
+
+type InvalidSnapshotError struct {
+	Reason string
+}
+
+type SnapshotVersionError struct {
+	Version uint32
+}
+
+type InvalidLogRecordError struct {
+	Reason string
+}
+
 func (e *DocumentNotFoundError) Error() string {
 	return fmt.Sprintf("Document with id '%s' not found", e.Id)
 }
@@ -25,3 +96,67 @@ func (e *DocumentAlreadyExistsError) Error() string {
 func (e *WrongSearchPropertyType) Error() string {
 	return fmt.Sprintf("Property '%s' is not searchable", e.Property)
 }
+
+func (e *WrongFilterPropertyType) Error() string {
+	return fmt.Sprintf("Property '%s' is not filterable", e.Property)
+}
+
+func (e *WrongFacetPropertyType) Error() string {
+	return fmt.Sprintf("Property '%s' cannot be faceted", e.Property)
+}
+
+func (e *WrongSortPropertyType) Error() string {
+	return fmt.Sprintf("Property '%s' is not sortable", e.Property)
+}
+
+func (e *SortOrderNotSupportedError) Error() string {
+	return fmt.Sprintf("Sort order '%s' is not supported", e.Order)
+}
+
+func (e *FusionNotSupportedError) Error() string {
+	return fmt.Sprintf("Fusion '%s' is not supported", e.Fusion)
+}
+
+func (e *ScoringNotSupportedError) Error() string {
+	return fmt.Sprintf("Scoring '%s' is not supported", e.Scoring)
+}
+
+func (e *AnalyzerNotSupportedError) Error() string {
+	return fmt.Sprintf("Analyzer '%s' is not supported", e.Analyzer)
+}
+
+func (e *InvalidIndexTagError) Error() string {
+	return fmt.Sprintf("Invalid index tag '%s' on field '%s': %s", e.Tag, e.Field, e.Reason)
+}
+
+func (e *UnsupportedFieldTypeError) Error() string {
+	return fmt.Sprintf("Field '%s' of type '%s' cannot be indexed", e.Field, e.Type)
+}
+
+func (e *VectorDimensionError) Error() string {
+	return fmt.Sprintf("Vector '%s' has %d dimensions, expected %d", e.Property, e.Actual, e.Expected)
+}
+
+func (e *WrongVectorPropertyType) Error() string {
+	return fmt.Sprintf("Property '%s' is not a vector", e.Property)
+}
+
+func (e *InvalidSearchParamsError) Error() string {
+	return fmt.Sprintf("Invalid search params: %s", e.Reason)
+}
+
+func (e *QuerySyntaxError) Error() string {
+	return fmt.Sprintf("Invalid query syntax at position %d: %s", e.Position, e.Reason)
+}
+
+func (e *InvalidSnapshotError) Error() string {
+	return fmt.Sprintf("Invalid snapshot: %s", e.Reason)
+}
+
+func (e *SnapshotVersionError) Error() string {
+	return fmt.Sprintf("Snapshot version %d is not supported", e.Version)
+}
+
+func (e *InvalidLogRecordError) Error() string {
+	return fmt.Sprintf("Invalid log record: %s", e.Reason)
+}
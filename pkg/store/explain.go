@@ -0,0 +1,38 @@
+package store
+
+// Explanation describes how the score of a search hit was computed. Compound nodes sum the scores
+// of their details, while "term" and "phrase" leaves hold the statistics passed to the scorer.
+type Explanation struct {
+	Score       float64
+	Description string
+	Token       string
+	Term        string
+	Penalty     float64
+	Fields      []FieldStats
+	Relevance   *BM25Params
+	Details     []*Explanation
+}
+
+type match struct {
+	score       float64
+	explanation *Explanation
+	hybrid      *HybridScores
+}
+
+// accumulate adds the match of a query clause to the compound match of the document.
+func accumulate(matches map[string]match, id string, m match, description string, ctx *queryContext) {
+	acc, ok := matches[id]
+	acc.score += m.score
+
+	if ctx.params.Explain {
+		if !ok {
+			acc.explanation = &Explanation{Description: description}
+		}
+		acc.explanation.Score = acc.score
+		if m.explanation != nil {
+			acc.explanation.Details = append(acc.explanation.Details, m.explanation)
+		}
+	}
+
+	matches[id] = acc
+}
@@ -0,0 +1,135 @@
+package store
+
+import "sort"
+
+const defaultFacetLimit = 10
+
+// FacetParams configure the facet of a keyword, bool or numeric property. Keyword and bool properties,
+// as well as numeric properties without Ranges, count their Limit most frequent values.
+type FacetParams struct {
+	Limit  int
+	Ranges []FacetRange
+}
+
+// FacetRange is a numeric bucket including From and excluding To, a nil bound is unlimited.
+type FacetRange struct {
+	From *float64
+	To   *float64
+}
+
+// FacetResult holds either the value counts or the range counts of a facet.
+// Count is the number of distinct values, of which only the most frequent are listed.
+type FacetResult struct {
+	Count  int
+	Values []FacetValue
+	Ranges []FacetRangeCount
+}
+
+type FacetValue struct {
+	Value any
+	Count int
+}
+
+type FacetRangeCount struct {
+	From  *float64
+	To    *float64
+	Count int
+}
+
+func (idx *index[K, S]) validateFacets(facets map[string]FacetParams) error {
+	for propName, params := range facets {
+		_, isNumber := idx.numbers[propName]
+		_, isKeyword := idx.keywords[propName]
+		if !isNumber && !isKeyword || isKeyword && len(params.Ranges) > 0 {
+			return &WrongFacetPropertyType{Property: propName}
+		}
+	}
+	return nil
+}
+
+// facets counts the values of the documents for every requested property.
+func (idx *index[K, S]) facets(ids []K, facets map[string]FacetParams) map[string]FacetResult {
+	results := make(map[string]FacetResult, len(facets))
+
+	for propName, params := range facets {
+		var values map[K][]any
+		if keywords, ok := idx.keywords[propName]; ok {
+			values = keywords.values
+		}
+
+		if numbers, ok := idx.numbers[propName]; ok {
+			if len(params.Ranges) > 0 {
+				results[propName] = numbers.facet(ids, params.Ranges)
+				continue
+			}
+
+			values = make(map[K][]any, len(ids))
+			for _, id := range ids {
+				for _, value := range numbers.values[id] {
+					values[id] = append(values[id], value)
+				}
+			}
+		}
+
+		results[propName] = countValues(ids, values, params.Limit)
+	}
+
+	return results
+}
+
+func (n *numericIndex[K]) facet(ids []K, ranges []FacetRange) FacetResult {
+	result := FacetResult{Ranges: make([]FacetRangeCount, len(ranges))}
+
+	for i, r := range ranges {
+		result.Ranges[i] = FacetRangeCount{From: r.From, To: r.To}
+	}
+
+	// a document with many values is counted once per range
+	for _, id := range ids {
+		for i, r := range ranges {
+			for _, value := range n.values[id] {
+				if (r.From == nil || value >= *r.From) && (r.To == nil || value < *r.To) {
+					result.Ranges[i].Count++
+					break
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func countValues[K recordId](ids []K, values map[K][]any, limit int) FacetResult {
+	counts := make(map[any]int)
+	for _, id := range ids {
+		seen := make(map[any]struct{}, len(values[id]))
+		for _, value := range values[id] {
+			if _, ok := seen[value]; !ok {
+				seen[value] = struct{}{}
+				counts[value]++
+			}
+		}
+	}
+
+	facetValues := make([]FacetValue, 0, len(counts))
+	for value, count := range counts {
+		facetValues = append(facetValues, FacetValue{Value: value, Count: count})
+	}
+
+	// the most frequent values go first, ties are broken by the value to keep the order stable
+	sort.Slice(facetValues, func(i, j int) bool {
+		if facetValues[i].Count != facetValues[j].Count {
+			return facetValues[i].Count > facetValues[j].Count
+		}
+		return compareValues(facetValues[i].Value, facetValues[j].Value) < 0
+	})
+
+	if limit <= 0 {
+		limit = defaultFacetLimit
+	}
+	if len(facetValues) > limit {
+		facetValues = facetValues[:limit]
+	}
+
+	return FacetResult{Count: len(counts), Values: facetValues}
+}
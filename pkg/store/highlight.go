@@ -0,0 +1,174 @@
+package store
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/micpst/minisearch/pkg/tokenizer"
+)
+
+const (
+	defaultPreTag       = "<em>"
+	defaultPostTag      = "</em>"
+	defaultFragmentSize = 100
+	defaultFragments    = 3
+)
+
+// HighlightParams request the fragments of the text properties around the words matching the query.
+// Properties default to the searched ones, and at most Fragments fragments of about FragmentSize
+// characters are returned per property, with the matched words wrapped in PreTag and PostTag.
+type HighlightParams struct {
+	Properties   []string
+	PreTag       string
+	PostTag      string
+	FragmentSize int
+	Fragments    int
+}
+
+// matchedTerms collects the indexed terms matched by a query for every property.
+type matchedTerms map[string]map[string]struct{}
+
+func (t matchedTerms) add(property string, term string) {
+	if t == nil {
+		return
+	}
+	if _, ok := t[property]; !ok {
+		t[property] = make(map[string]struct{})
+	}
+	t[property][term] = struct{}{}
+}
+
+func (db *MemDB[S]) validateHighlight(params *HighlightParams) error {
+	for _, prop := range params.Properties {
+		if _, ok := db.index.indexes[prop]; !ok {
+			return &WrongSearchPropertyType{Property: prop}
+		}
+	}
+	return nil
+}
+
+// highlight returns the fragments of the document properties containing the matched terms, analyzing the document
+// in the language it is indexed with.
+func (db *MemDB[S]) highlight(document S, terms matchedTerms, properties []string, language tokenizer.Language, params *HighlightParams) map[string][]string {
+	if len(params.Properties) > 0 {
+		properties = params.Properties
+	}
+
+	preTag, postTag := params.PreTag, params.PostTag
+	if preTag == "" && postTag == "" {
+		preTag, postTag = defaultPreTag, defaultPostTag
+	}
+	size := params.FragmentSize
+	if size <= 0 {
+		size = defaultFragmentSize
+	}
+	limit := params.Fragments
+	if limit <= 0 {
+		limit = defaultFragments
+	}
+
+	fields := flattenSchema(document)
+	highlights := make(map[string][]string)
+
+	for _, prop := range properties {
+		propTerms := terms[prop]
+		if len(propTerms) == 0 {
+			continue
+		}
+
+		for _, value := range values(fields[prop]) {
+			text := value.(string)
+			tokens := db.analyzer(prop, language).TokenizeWithOffsets(&tokenizer.AnalyzeParams{
+				Text:            text,
+				AllowDuplicates: true,
+			})
+
+			spans := make([][2]int, 0)
+			for _, token := range tokens {
+				if _, ok := propTerms[token.Value]; ok {
+					spans = append(spans, [2]int{token.Start, token.End})
+				}
+			}
+
+			remaining := limit - len(highlights[prop])
+			highlights[prop] = append(highlights[prop], fragments(text, spans, size, preTag, postTag, remaining)...)
+			if len(highlights[prop]) == limit {
+				break
+			}
+		}
+
+		if len(highlights[prop]) == 0 {
+			delete(highlights, prop)
+		}
+	}
+
+	return highlights
+}
+
+// fragments cuts the text into at most limit fragments around the ordered spans, wrapping every span in tags.
+func fragments(text string, spans [][2]int, size int, preTag string, postTag string, limit int) []string {
+	result := make([]string, 0)
+
+	for i, last := 0, 0; i < len(spans) && len(result) < limit; {
+		start, end := fragmentBounds(text, spans[i], size)
+		// fragments do not overlap, the words of the previous one are not repeated
+		start = max(start, last)
+
+		b := strings.Builder{}
+		pos := start
+		for ; i < len(spans) && spans[i][1] <= end; i++ {
+			b.WriteString(text[pos:spans[i][0]])
+			b.WriteString(preTag)
+			b.WriteString(text[spans[i][0]:spans[i][1]])
+			b.WriteString(postTag)
+			pos = spans[i][1]
+		}
+
+		// a span crossing the end of the fragment is left whole to the next one
+		if i < len(spans) && spans[i][0] < end {
+			end = spans[i][0]
+		}
+		b.WriteString(text[pos:end])
+		last = end
+
+		result = append(result, strings.TrimSpace(b.String()))
+	}
+
+	return result
+}
+
+// fragmentBounds returns the byte range of about size characters centered on the span, without cutting words.
+func fragmentBounds(text string, span [2]int, size int) (int, int) {
+	budget := size - utf8.RuneCountInString(text[span[0]:span[1]])
+
+	start := span[0]
+	for n, before := 0, budget/2; n < before && start > 0; n++ {
+		_, width := utf8.DecodeLastRuneInString(text[:start])
+		start -= width
+		budget--
+	}
+
+	end := span[1]
+	for n := 0; n < budget && end < len(text); n++ {
+		_, width := utf8.DecodeRuneInString(text[end:])
+		end += width
+	}
+
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && !unicode.IsSpace(before) {
+		if i := strings.IndexFunc(text[start:span[0]], unicode.IsSpace); i >= 0 {
+			start += i
+		} else {
+			start = span[0]
+		}
+	}
+	if after, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && !unicode.IsSpace(after) {
+		if i := strings.LastIndexFunc(text[span[1]:end], unicode.IsSpace); i >= 0 {
+			end = span[1] + i
+		} else {
+			end = span[1]
+		}
+	}
+
+	return start, end
+}
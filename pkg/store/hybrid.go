@@ -0,0 +1,156 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	RRF      Fusion = "rrf"
+	WEIGHTED Fusion = "weighted"
+)
+
+const (
+	defaultAlpha        = 0.5
+	defaultRankConstant = 60
+)
+
+type Fusion string
+
+// HybridParams configure how the results of a query and a vector search are fused. RRF sums
+// 1 / (RankConstant + rank) over both result lists, while WEIGHTED blends the min-max normalized
+// scores as (1 - Alpha) * text + Alpha * vector. A nil Alpha and a zero RankConstant select the default of 0.5 and 60.
+type HybridParams struct {
+	Fusion       Fusion
+	Alpha        *float64
+	RankConstant int
+}
+
+// HybridScores hold the component scores of a hybrid search hit and its 1-based ranks in
+// the text and vector results. A zero rank means the hit is missing from those results.
+type HybridScores struct {
+	Text       float64
+	TextRank   int
+	Vector     float64
+	VectorRank int
+}
+
+type rankedMatch struct {
+	id string
+	match
+	rank int
+}
+
+func validateHybrid(params *HybridParams) error {
+	if params.Fusion != "" && params.Fusion != RRF && params.Fusion != WEIGHTED {
+		return &FusionNotSupportedError{Fusion: params.Fusion}
+	}
+	if params.Alpha != nil && (*params.Alpha < 0 || *params.Alpha > 1) {
+		return &InvalidSearchParamsError{Reason: "alpha must be between 0 and 1"}
+	}
+	if params.RankConstant < 0 {
+		return &InvalidSearchParamsError{Reason: "rank constant must be non-negative"}
+	}
+	return nil
+}
+
+// fuse combines the text and vector matches of a hybrid search into a single score per document.
+func fuse(text map[string]match, vector map[string]match, params *HybridParams, explain bool) map[string]match {
+	fusion := params.Fusion
+	if fusion == "" {
+		fusion = RRF
+	}
+
+	fused := make(map[string]match, len(text)+len(vector))
+	scores := make(map[string]*HybridScores, len(text)+len(vector))
+
+	for i, matches := range []map[string]match{text, vector} {
+		ranked := rank(matches)
+		normalize := normalizer(ranked)
+
+		for _, m := range ranked {
+			hybrid, ok := scores[m.id]
+			if !ok {
+				hybrid = &HybridScores{}
+				scores[m.id] = hybrid
+			}
+
+			var score float64
+			switch fusion {
+			case RRF:
+				rankConstant := params.RankConstant
+				if rankConstant == 0 {
+					rankConstant = defaultRankConstant
+				}
+				score = 1 / float64(rankConstant+m.rank)
+			case WEIGHTED:
+				alpha := defaultAlpha
+				if params.Alpha != nil {
+					alpha = *params.Alpha
+				}
+				weight := 1 - alpha
+				if i == 1 {
+					weight = alpha
+				}
+				score = weight * normalize(m.score)
+			}
+
+			if i == 0 {
+				hybrid.Text, hybrid.TextRank = m.score, m.rank
+			} else {
+				hybrid.Vector, hybrid.VectorRank = m.score, m.rank
+			}
+
+			acc := fused[m.id]
+			acc.score += score
+			acc.hybrid = hybrid
+			if explain {
+				if acc.explanation == nil {
+					acc.explanation = &Explanation{Description: fmt.Sprintf("%s fusion of", fusion)}
+				}
+				acc.explanation.Score = acc.score
+				if m.explanation != nil {
+					acc.explanation.Details = append(acc.explanation.Details, m.explanation)
+				}
+			}
+			fused[m.id] = acc
+		}
+	}
+
+	return fused
+}
+
+// rank orders the matches by score, breaking ties by id like the search hits.
+func rank(matches map[string]match) []rankedMatch {
+	ranked := make([]rankedMatch, 0, len(matches))
+	for id, m := range matches {
+		ranked = append(ranked, rankedMatch{id: id, match: m})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].id < ranked[j].id
+	})
+	for i := range ranked {
+		ranked[i].rank = i + 1
+	}
+
+	return ranked
+}
+
+// normalizer maps the scores of ranked matches to [0, 1], all of them to 1 if they are equal.
+func normalizer(ranked []rankedMatch) func(float64) float64 {
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	highest, lowest := ranked[0].score, ranked[len(ranked)-1].score
+	return func(score float64) float64 {
+		if highest == lowest {
+			return 1
+		}
+		return (score - lowest) / (highest - lowest)
+	}
+}
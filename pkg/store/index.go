@@ -1,18 +1,24 @@
 package store
 
 import (
-	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
+	"github.com/micpst/minisearch/pkg/hnsw"
 	"github.com/micpst/minisearch/pkg/lib"
 	"github.com/micpst/minisearch/pkg/radix"
 	"github.com/micpst/minisearch/pkg/tokenizer"
 )
 
+// positionGap separates the positions of the values of a multi-valued property.
+const positionGap = 100
+
 type recordId comparable
 
 type recordInfo struct {
 	termFrequency float64
+	positions     []int
 }
 
 type findParams struct {
@@ -20,92 +26,149 @@ type findParams struct {
 	property  string
 	exact     bool
 	tolerance int
-	relevance BM25Params
-	docsCount int
+}
+
+type findResult[K recordId] struct {
+	term     string
+	distance int
+	idStats  map[K]FieldStats
+}
+
+type phraseParams struct {
+	tokens   []string
+	property string
+	slop     int
 }
 
 type indexParams[K recordId, S Schema] struct {
-	id              K
-	document        S
-	docsCount       int
-	language        tokenizer.Language
-	tokenizerConfig *tokenizer.Config
+	id        K
+	document  S
+	docsCount int
+	analyzers map[string]*tokenizer.Analyzer
 }
 
 type index[K recordId, S Schema] struct {
 	indexes              map[string]*radix.Trie[K, recordInfo]
+	numbers              map[string]*numericIndex[K]
+	keywords             map[string]*keywordIndex[K]
+	vectors              map[string]*vectorIndex[K]
+	properties           map[string]*property
 	searchableProperties []string
 	avgFieldLength       map[string]float64
 	fieldLengths         map[string]map[K]int
 	tokenOccurrences     map[string]map[string]int
+	languageProperty     string
 }
 
-func newIndex[K recordId, S Schema]() *index[K, S] {
+func newIndex[K recordId, S Schema](graph *hnsw.Config) (*index[K, S], error) {
+	var s S
+	properties, err := parseSchema(reflect.TypeOf(s))
+	if err != nil {
+		return nil, err
+	}
+
 	idx := &index[K, S]{
 		indexes:              make(map[string]*radix.Trie[K, recordInfo]),
+		numbers:              make(map[string]*numericIndex[K]),
+		keywords:             make(map[string]*keywordIndex[K]),
+		vectors:              make(map[string]*vectorIndex[K]),
+		properties:           properties,
 		searchableProperties: make([]string, 0),
 		avgFieldLength:       make(map[string]float64),
 		fieldLengths:         make(map[string]map[K]int),
 		tokenOccurrences:     make(map[string]map[string]int),
 	}
-	idx.build()
-	return idx
+	idx.build(graph)
+	return idx, nil
 }
 
-func (idx *index[K, S]) build() {
-	var s S
-	for key, value := range flattenSchema(s) {
-		switch value.(type) {
-		case string:
-			idx.indexes[key] = radix.New[K, recordInfo]()
-			idx.fieldLengths[key] = make(map[K]int)
-			idx.tokenOccurrences[key] = make(map[string]int)
-			idx.searchableProperties = append(idx.searchableProperties, key)
-		default:
-			continue
+func (idx *index[K, S]) build(graph *hnsw.Config) {
+	for propName, prop := range idx.properties {
+		switch prop.kind {
+		case textProperty:
+			idx.indexes[propName] = radix.New[K, recordInfo]()
+			idx.fieldLengths[propName] = make(map[K]int)
+			idx.tokenOccurrences[propName] = make(map[string]int)
+			idx.searchableProperties = append(idx.searchableProperties, propName)
+		case numberProperty:
+			idx.numbers[propName] = newNumericIndex[K]()
+		case keywordProperty, boolProperty:
+			idx.keywords[propName] = newKeywordIndex[K]()
+			if prop.language {
+				idx.languageProperty = propName
+			}
+		case vectorProperty:
+			idx.vectors[propName] = newVectorIndex[K](prop.dims, prop.metric, graph)
 		}
 	}
 }
 
 func (idx *index[K, S]) insert(params *indexParams[K, S]) {
 	document := flattenSchema(params.document)
+	idx.insertValues(params.id, document)
 
 	for propName, index := range idx.indexes {
-		tokens, _ := tokenizer.Tokenize(&tokenizer.TokenizeParams{
-			Text:            document[propName].(string),
-			Language:        params.language,
-			AllowDuplicates: true,
-		}, params.tokenizerConfig)
+		allTokensCount := 0
+		tokensPositions := make(map[string][]int)
+
+		// the values of a multi-valued property are separated by a gap, so phrases do not match across them
+		position := 0
+		for _, text := range values(document[propName]) {
+			tokens := params.analyzers[propName].Tokenize(&tokenizer.AnalyzeParams{
+				Text:            text.(string),
+				AllowDuplicates: true,
+			})
 
-		allTokensCount := float64(len(tokens))
-		tokensCount := lib.Count(tokens)
+			for _, token := range tokens {
+				tokensPositions[token] = append(tokensPositions[token], position)
+				position++
+			}
+			position += positionGap
+			allTokensCount += len(tokens)
+		}
 
-		for token, count := range tokensCount {
-			tokenFrequency := float64(count) / allTokensCount
+		for token, positions := range tokensPositions {
+			tokenFrequency := float64(len(positions)) / float64(allTokensCount)
 			index.Insert(&radix.InsertParams[K, recordInfo]{
 				Id:   params.id,
 				Word: token,
-				Data: recordInfo{termFrequency: tokenFrequency},
+				Data: recordInfo{termFrequency: tokenFrequency, positions: positions},
 			})
 			idx.tokenOccurrences[propName][token]++
 		}
 
-		idx.avgFieldLength[propName] = (idx.avgFieldLength[propName]*float64(params.docsCount-1) + allTokensCount) / float64(params.docsCount)
-		idx.fieldLengths[propName][params.id] = int(allTokensCount)
+		idx.avgFieldLength[propName] = (idx.avgFieldLength[propName]*float64(params.docsCount-1) + float64(allTokensCount)) / float64(params.docsCount)
+		idx.fieldLengths[propName][params.id] = allTokensCount
 	}
 }
 
 func (idx *index[K, S]) delete(params *indexParams[K, S]) {
 	document := flattenSchema(params.document)
 
+	for _, numbers := range idx.numbers {
+		numbers.delete(params.id)
+	}
+	for _, keywords := range idx.keywords {
+		keywords.delete(params.id)
+	}
+	for _, vectors := range idx.vectors {
+		vectors.delete(params.id)
+	}
+
 	for propName, index := range idx.indexes {
-		tokens, _ := tokenizer.Tokenize(&tokenizer.TokenizeParams{
-			Text:            document[propName].(string),
-			Language:        params.language,
-			AllowDuplicates: false,
-		}, params.tokenizerConfig)
+		uniqueTokens := make(map[string]struct{})
+		for _, text := range values(document[propName]) {
+			tokens := params.analyzers[propName].Tokenize(&tokenizer.AnalyzeParams{
+				Text:            text.(string),
+				AllowDuplicates: false,
+			})
+
+			for _, token := range tokens {
+				uniqueTokens[token] = struct{}{}
+			}
+		}
 
-		for _, token := range tokens {
+		for token := range uniqueTokens {
 			index.Delete(&radix.DeleteParams[K]{
 				Id:   params.id,
 				Word: token,
@@ -116,65 +179,320 @@ func (idx *index[K, S]) delete(params *indexParams[K, S]) {
 			}
 		}
 
-		idx.avgFieldLength[propName] = (idx.avgFieldLength[propName]*float64(params.docsCount) - float64(len(tokens))) / float64(params.docsCount-1)
+		if params.docsCount > 1 {
+			idx.avgFieldLength[propName] = (idx.avgFieldLength[propName]*float64(params.docsCount) - float64(idx.fieldLengths[propName][params.id])) / float64(params.docsCount-1)
+		} else {
+			idx.avgFieldLength[propName] = 0
+		}
 		delete(idx.fieldLengths[propName], params.id)
 	}
 }
 
-func (idx *index[K, S]) find(params *findParams) (map[K]float64, error) {
-	idScores := make(map[K]float64)
+func (idx *index[K, S]) insertValues(id K, document map[string]any) {
+	for propName, numbers := range idx.numbers {
+		for _, value := range numericValues(document[propName]) {
+			numbers.insert(id, value)
+		}
+	}
+	idx.insertKeywordsAndVectors(id, document)
+}
 
-	if index, ok := idx.indexes[params.property]; ok {
-		records := index.Find(&radix.FindParams{
-			Term:      params.term,
-			Tolerance: params.tolerance,
-			Exact:     params.exact,
-		})
-		for id, data := range records {
-			idScores[id] = lib.BM25(
-				data.termFrequency,
-				idx.tokenOccurrences[params.property][params.term],
-				idx.fieldLengths[params.property][id],
-				idx.avgFieldLength[params.property],
-				params.docsCount,
-				params.relevance.K,
-				params.relevance.B,
-				params.relevance.D,
-			)
-		}
-	} else {
+// loadValues indexes the values of many documents at once, every numeric index is sorted a single time.
+func (idx *index[K, S]) loadValues(documents map[K]S) {
+	entries := make(map[string][]numericEntry[K], len(idx.numbers))
+	for id, doc := range documents {
+		document := flattenSchema(doc)
+		for propName := range idx.numbers {
+			for _, value := range numericValues(document[propName]) {
+				entries[propName] = append(entries[propName], numericEntry[K]{value: value, id: id})
+			}
+		}
+		idx.insertKeywordsAndVectors(id, document)
+	}
+	for propName, numbers := range idx.numbers {
+		numbers.load(entries[propName])
+	}
+}
+
+func (idx *index[K, S]) insertKeywordsAndVectors(id K, document map[string]any) {
+	for propName, keywords := range idx.keywords {
+		for _, value := range values(document[propName]) {
+			keywords.insert(id, value)
+		}
+	}
+	for propName, vectors := range idx.vectors {
+		if vector, ok := document[propName].([]float32); ok && len(vector) > 0 {
+			vectors.insert(id, vector)
+		}
+	}
+}
+
+// numericValues returns the values of a numeric property as floats.
+func numericValues(value any) []float64 {
+	result := make([]float64, 0)
+	for _, value := range values(value) {
+		switch value := value.(type) {
+		case int64:
+			result = append(result, float64(value))
+		case uint64:
+			result = append(result, float64(value))
+		case float64:
+			result = append(result, value)
+		case []float32:
+			for _, v := range value {
+				result = append(result, float64(v))
+			}
+		}
+	}
+	return result
+}
+
+// validate checks the values of a document that cannot be indexed as they are.
+func (idx *index[K, S]) validate(document S) error {
+	values := flattenSchema(document)
+	for propName, vectors := range idx.vectors {
+		if vector, ok := values[propName].([]float32); ok && len(vector) > 0 && len(vector) != vectors.dims {
+			return &VectorDimensionError{Property: propName, Expected: vectors.dims, Actual: len(vector)}
+		}
+	}
+	if language := idx.language(values); !isSupportedLanguage(language) {
+		return &tokenizer.LanguageNotSupportedError{Language: language}
+	}
+	return nil
+}
+
+// language returns the value of the language property of a flattened document, if any.
+func (idx *index[K, S]) language(document map[string]any) tokenizer.Language {
+	if idx.languageProperty == "" {
+		return ""
+	}
+	language, _ := document[idx.languageProperty].(string)
+	return tokenizer.Language(strings.ToLower(language))
+}
+
+// filter returns the ids of the documents matching all the filters, or nil if there are none.
+func (idx *index[K, S]) filter(filters *Filters) (map[K]struct{}, error) {
+	var ids map[K]struct{}
+
+	intersect := func(matches map[K]struct{}) {
+		if ids == nil {
+			ids = matches
+			return
+		}
+		for id := range ids {
+			if _, ok := matches[id]; !ok {
+				delete(ids, id)
+			}
+		}
+	}
+
+	for propName, filter := range filters.Range {
+		numbers, ok := idx.numbers[propName]
+		if !ok {
+			return nil, &WrongFilterPropertyType{Property: propName}
+		}
+		intersect(numbers.find(&filter))
+	}
+
+	for propName, value := range filters.Term {
+		keywords, ok := idx.keywords[propName]
+		if !ok {
+			return nil, &WrongFilterPropertyType{Property: propName}
+		}
+		intersect(keywords.find([]any{value}))
+	}
+
+	for propName, values := range filters.Terms {
+		keywords, ok := idx.keywords[propName]
+		if !ok {
+			return nil, &WrongFilterPropertyType{Property: propName}
+		}
+		intersect(keywords.find(values))
+	}
+
+	return ids, nil
+}
+
+func (idx *index[K, S]) find(params *findParams) ([]findResult[K], error) {
+	index, ok := idx.indexes[params.property]
+	if !ok {
 		return nil, &WrongSearchPropertyType{Property: params.property}
 	}
 
-	return idScores, nil
+	records := index.Find(&radix.FindParams{
+		Term:      params.term,
+		Tolerance: params.tolerance,
+		Exact:     params.exact,
+	})
+
+	results := make([]findResult[K], 0, len(records))
+	for _, record := range records {
+		idStats := make(map[K]FieldStats, len(record.Data))
+		for id, data := range record.Data {
+			idStats[id] = FieldStats{
+				Property:          params.property,
+				TermFrequency:     data.termFrequency,
+				DocumentFrequency: idx.tokenOccurrences[params.property][record.Term],
+				FieldLength:       idx.fieldLengths[params.property][id],
+				AvgFieldLength:    idx.avgFieldLength[params.property],
+			}
+		}
+		results = append(results, findResult[K]{
+			term:     record.Term,
+			distance: record.Distance,
+			idStats:  idStats,
+		})
+	}
+
+	return results, nil
 }
 
-func flattenSchema(obj any, prefix ...string) map[string]any {
-	m := make(map[string]any)
-	t := reflect.TypeOf(obj)
-	v := reflect.ValueOf(obj)
-	fields := reflect.VisibleFields(t)
-
-	for i, field := range fields {
-		if propName, ok := field.Tag.Lookup("index"); ok {
-			if len(prefix) == 1 {
-				propName = fmt.Sprintf("%s.%s", prefix[0], propName)
+func (idx *index[K, S]) phrase(params *phraseParams) (map[K]FieldStats, error) {
+	index, ok := idx.indexes[params.property]
+	if !ok {
+		return nil, &WrongSearchPropertyType{Property: params.property}
+	}
+
+	var candidates map[K][][]int
+
+	for i, token := range params.tokens {
+		var records map[K]recordInfo
+		if results := index.Find(&radix.FindParams{Term: token, Exact: true}); len(results) > 0 {
+			records = results[0].Data
+		}
+
+		if i == 0 {
+			candidates = make(map[K][][]int, len(records))
+			for id, data := range records {
+				candidates[id] = [][]int{data.positions}
 			}
+			continue
+		}
 
-			switch field.Type.Kind() {
-			case reflect.Struct:
-				for key, value := range flattenSchema(v.Field(i).Interface(), propName) {
-					m[key] = value
-				}
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				m[propName] = v.Field(i).Int()
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				m[propName] = v.Field(i).Uint()
-			default:
-				m[propName] = v.Field(i).String()
+		for id, positions := range candidates {
+			if data, ok := records[id]; ok {
+				candidates[id] = append(positions, data.positions)
+			} else {
+				delete(candidates, id)
 			}
 		}
 	}
 
+	// the slop stays below the gap between the values of a multi-valued property, so phrases do not span them
+	slop := min(params.slop, positionGap-1)
+	frequencies := make(map[K]float64, len(candidates))
+	for id, positions := range candidates {
+		if frequency := lib.PhraseFrequency(positions, slop); frequency > 0 {
+			frequencies[id] = frequency
+		}
+	}
+
+	// the phrase is scored as a single pseudo-term occurring in the matching documents only
+	idStats := make(map[K]FieldStats, len(frequencies))
+	for id, frequency := range frequencies {
+		idStats[id] = FieldStats{
+			Property:          params.property,
+			TermFrequency:     frequency / float64(idx.fieldLengths[params.property][id]),
+			DocumentFrequency: len(frequencies),
+			FieldLength:       idx.fieldLengths[params.property][id],
+			AvgFieldLength:    idx.avgFieldLength[params.property],
+		}
+	}
+
+	return idStats, nil
+}
+
+func (idx *index[K, S]) boost(property string, boosts map[string]float64) float64 {
+	if boost, ok := boosts[property]; ok {
+		return boost
+	}
+	if prop, ok := idx.properties[property]; ok {
+		return prop.boost
+	}
+	return 1
+}
+
+// flattenSchema maps the indexed properties of a document to their values. Multi-valued properties,
+// i.e. slices and the properties nested in them, hold a []any, while nil pointers are left out.
+func flattenSchema(obj any, prefix ...string) map[string]any {
+	m := make(map[string]any)
+	propPrefix := ""
+	if len(prefix) == 1 {
+		propPrefix = prefix[0] + "."
+	}
+	flattenStruct(m, reflect.ValueOf(obj), propPrefix, false)
 	return m
 }
+
+func flattenStruct(m map[string]any, v reflect.Value, prefix string, multi bool) {
+	for _, field := range reflect.VisibleFields(v.Type()) {
+		tag, ok := field.Tag.Lookup("index")
+		if !ok {
+			continue
+		}
+
+		value, err := v.FieldByIndexErr(field.Index)
+		if err != nil {
+			continue
+		}
+		flattenValue(m, value, prefix+parseIndexTag(tag).name, multi)
+	}
+}
+
+func flattenValue(m map[string]any, v reflect.Value, propName string, multi bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		// vectors are indexed as a whole
+		if v.Type() == vectorType {
+			setValue(m, propName, v.Interface(), multi)
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			flattenValue(m, v.Index(i), propName, true)
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			setValue(m, propName, v.Interface().(time.Time).Unix(), multi)
+		} else {
+			flattenStruct(m, v, propName+".", multi)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		setValue(m, propName, v.Int(), multi)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		setValue(m, propName, v.Uint(), multi)
+	case reflect.Float32, reflect.Float64:
+		setValue(m, propName, v.Float(), multi)
+	case reflect.Bool:
+		setValue(m, propName, v.Bool(), multi)
+	case reflect.String:
+		setValue(m, propName, v.String(), multi)
+	}
+}
+
+func setValue(m map[string]any, propName string, value any, multi bool) {
+	if !multi {
+		m[propName] = value
+		return
+	}
+	list, _ := m[propName].([]any)
+	m[propName] = append(list, value)
+}
+
+// values returns the values of a flattened property, whether it is single or multi-valued.
+func values(value any) []any {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return value
+	default:
+		return []any{value}
+	}
+}
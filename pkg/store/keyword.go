@@ -0,0 +1,46 @@
+package store
+
+// keywordIndex maps the exact values of a keyword or bool property to the documents holding them.
+type keywordIndex[K recordId] struct {
+	ids    map[any]map[K]struct{}
+	values map[K][]any
+}
+
+func newKeywordIndex[K recordId]() *keywordIndex[K] {
+	return &keywordIndex[K]{
+		ids:    make(map[any]map[K]struct{}),
+		values: make(map[K][]any),
+	}
+}
+
+func (k *keywordIndex[K]) insert(id K, value any) {
+	if _, ok := k.ids[value]; !ok {
+		k.ids[value] = make(map[K]struct{})
+	}
+	if _, ok := k.ids[value][id]; ok {
+		return
+	}
+	k.ids[value][id] = struct{}{}
+	k.values[id] = append(k.values[id], value)
+}
+
+func (k *keywordIndex[K]) delete(id K) {
+	for _, value := range k.values[id] {
+		delete(k.ids[value], id)
+		if len(k.ids[value]) == 0 {
+			delete(k.ids, value)
+		}
+	}
+	delete(k.values, id)
+}
+
+// find returns the ids of the documents holding any of the values.
+func (k *keywordIndex[K]) find(values []any) map[K]struct{} {
+	ids := make(map[K]struct{})
+	for _, value := range values {
+		for id := range k.ids[value] {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/micpst/minisearch/pkg/tokenizer"
+)
+
+const (
+	// minDetectionLength is the number of letters below which a text is too short to detect its language.
+	minDetectionLength = 20
+	// minDetectionConfidence is the confidence below which a detected language is not trusted.
+	minDetectionConfidence = 0.5
+)
+
+// isSupportedLanguage reports whether a requested language can be used, an empty one stands for the default.
+func isSupportedLanguage(language tokenizer.Language) bool {
+	return language == "" || language == tokenizer.AUTO || tokenizer.IsSupportedLanguage(language)
+}
+
+// documentLanguage returns the language a document is indexed with: the value of its language property,
+// else the requested language, else the fallback. The language of a document requesting tokenizer.AUTO
+// is detected from its text properties, along with the confidence of the detection.
+func (db *MemDB[S]) documentLanguage(document S, language tokenizer.Language, fallback tokenizer.Language) (tokenizer.Language, float64) {
+	fields := flattenSchema(document)
+	if documentLanguage := db.index.language(fields); documentLanguage != "" {
+		language = documentLanguage
+	}
+
+	switch language {
+	case "":
+		return fallback, 0
+	case tokenizer.AUTO:
+		texts := make([]string, 0, len(db.index.indexes))
+		for prop := range db.index.indexes {
+			for _, value := range values(fields[prop]) {
+				texts = append(texts, value.(string))
+			}
+		}
+		return detectLanguage(strings.Join(texts, "\n"), fallback)
+	default:
+		return language, 0
+	}
+}
+
+// queryLanguage returns the language a query is analyzed in, detected from its words and phrases
+// if tokenizer.AUTO is requested.
+func (db *MemDB[S]) queryLanguage(query string, language tokenizer.Language) (tokenizer.Language, float64) {
+	switch language {
+	case "":
+		return db.defaultLanguage, 0
+	case tokenizer.AUTO:
+		// the field names and operators are not part of the text
+		lexemes, err := lexQuery(query)
+		if err != nil {
+			return detectLanguage(query, db.defaultLanguage)
+		}
+		texts := make([]string, 0, len(lexemes))
+		for _, l := range lexemes {
+			if l.kind == wordLexeme || l.kind == phraseLexeme {
+				texts = append(texts, l.text)
+			}
+		}
+		return detectLanguage(strings.Join(texts, " "), db.defaultLanguage)
+	default:
+		return language, 0
+	}
+}
+
+// detectLanguage returns the detected language of the text, or the fallback if the text is too short
+// or the detection is not confident enough.
+func detectLanguage(text string, fallback tokenizer.Language) (tokenizer.Language, float64) {
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	if letters < minDetectionLength {
+		return fallback, 0
+	}
+
+	detection := tokenizer.DetectLanguage(text)
+	if detection.Language == "" || detection.Confidence < minDetectionConfidence {
+		return fallback, 0
+	}
+	return detection.Language, detection.Confidence
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+
+	"github.com/micpst/minisearch/pkg/tokenizer"
+)
+
+type operation uint8
+
+const (
+	insertOperation operation = iota + 1
+	updateOperation
+	deleteOperation
+)
+
+// logRecord stores the language a document is indexed with, deletes use the one of the deleted document.
+type logRecord[S Schema] struct {
+	Operation operation
+	Id        string
+	Document  S
+	Language  tokenizer.Language
+}
+
+// Replay applies every operation stored in the log on top of the current state.
+// Operations are applied idempotently, so replaying a log that partially overlaps
+// with the loaded snapshot converges to the same state. The documents are analyzed
+// with the current settings, so their languages must still be supported.
+func (db *MemDB[S]) Replay() error {
+	if db.log == nil {
+		return nil
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	return db.log.Replay(func(data []byte) error {
+		record := logRecord[S]{}
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&record); err != nil {
+			return &InvalidLogRecordError{Reason: err.Error()}
+		}
+
+		_, exists := db.documents[record.Id]
+
+		switch record.Operation {
+		case insertOperation, updateOperation:
+			if !tokenizer.IsSupportedLanguage(record.Language) {
+				return &InvalidLogRecordError{Reason: "language '" + string(record.Language) + "' is not supported"}
+			}
+			if exists {
+				db.update(record.Id, record.Document, record.Language)
+			} else {
+				db.insert(record.Id, record.Document, record.Language)
+			}
+		case deleteOperation:
+			if exists {
+				db.delete(record.Id)
+			}
+		default:
+			return &InvalidLogRecordError{Reason: "unknown operation"}
+		}
+
+		return nil
+	})
+}
+
+// Checkpoint writes a snapshot to w, calls commit to make it durable and truncates the log.
+// No mutations are accepted until the checkpoint completes.
+func (db *MemDB[S]) Checkpoint(w io.Writer, commit func() error) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.save(w); err != nil {
+		return err
+	}
+	if err := commit(); err != nil {
+		return err
+	}
+	if db.log == nil {
+		return nil
+	}
+
+	return db.log.Truncate()
+}
+
+func (db *MemDB[S]) writeLog(record *logRecord[S]) error {
+	if db.log == nil {
+		return nil
+	}
+
+	buf := bytes.Buffer{}
+	if err := gob.NewEncoder(&buf).Encode(record); err != nil {
+		return err
+	}
+
+	return db.log.Append(buf.Bytes())
+}
@@ -0,0 +1,184 @@
+package store
+
+import (
+	"math"
+	"sort"
+)
+
+// numericChunkSize is the size of the chunks of a numeric index, a chunk twice as large is split in two.
+const numericChunkSize = 256
+
+type numericEntry[K recordId] struct {
+	value float64
+	id    K
+}
+
+// numericIndex keeps the values of a numeric property sorted in chunks, so that range filters are answered
+// with binary searches and an insert or a delete only moves the entries of one chunk.
+type numericIndex[K recordId] struct {
+	chunks [][]numericEntry[K]
+	values map[K][]float64
+}
+
+func newNumericIndex[K recordId]() *numericIndex[K] {
+	return &numericIndex[K]{
+		chunks: make([][]numericEntry[K], 0),
+		values: make(map[K][]float64),
+	}
+}
+
+func (n *numericIndex[K]) insert(id K, value float64) {
+	n.values[id] = append(n.values[id], value)
+
+	entry := numericEntry[K]{value: value, id: id}
+	if len(n.chunks) == 0 {
+		n.chunks = append(n.chunks, []numericEntry[K]{entry})
+		return
+	}
+
+	// the entry goes to the first chunk ending after its value, or to the last one
+	c := min(n.chunkAfter(value), len(n.chunks)-1)
+	chunk := n.chunks[c]
+	i := sort.Search(len(chunk), func(i int) bool { return chunk[i].value > value })
+	chunk = append(chunk, numericEntry[K]{})
+	copy(chunk[i+1:], chunk[i:])
+	chunk[i] = entry
+
+	if len(chunk) < 2*numericChunkSize {
+		n.chunks[c] = chunk
+		return
+	}
+	half := len(chunk) / 2
+	n.chunks = append(n.chunks, nil)
+	copy(n.chunks[c+2:], n.chunks[c+1:])
+	n.chunks[c] = chunk[:half:half]
+	n.chunks[c+1] = append(make([]numericEntry[K], 0, numericChunkSize), chunk[half:]...)
+}
+
+// load replaces the entries of the index with the values of many documents, sorted once.
+func (n *numericIndex[K]) load(entries []numericEntry[K]) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].value < entries[j].value
+	})
+
+	n.chunks = make([][]numericEntry[K], 0, len(entries)/numericChunkSize+1)
+	n.values = make(map[K][]float64)
+	for start := 0; start < len(entries); start += numericChunkSize {
+		end := min(start+numericChunkSize, len(entries))
+		n.chunks = append(n.chunks, entries[start:end:end])
+	}
+	for _, entry := range entries {
+		n.values[entry.id] = append(n.values[entry.id], entry.value)
+	}
+}
+
+func (n *numericIndex[K]) delete(id K) {
+	for _, value := range n.values[id] {
+		n.remove(id, value)
+	}
+	delete(n.values, id)
+}
+
+// remove deletes an entry, the entries with the same value may span several chunks.
+func (n *numericIndex[K]) remove(id K, value float64) {
+	for c := n.chunkFrom(value, true); c < len(n.chunks); c++ {
+		chunk := n.chunks[c]
+		for i := sort.Search(len(chunk), func(i int) bool { return chunk[i].value >= value }); i < len(chunk); i++ {
+			if chunk[i].value != value {
+				return
+			}
+			if chunk[i].id != id {
+				continue
+			}
+			if len(chunk) == 1 {
+				n.chunks = append(n.chunks[:c], n.chunks[c+1:]...)
+			} else {
+				n.chunks[c] = append(chunk[:i], chunk[i+1:]...)
+			}
+			return
+		}
+	}
+}
+
+// chunkAfter returns the first chunk ending with a value greater than the given one.
+func (n *numericIndex[K]) chunkAfter(value float64) int {
+	return sort.Search(len(n.chunks), func(c int) bool {
+		return n.chunks[c][len(n.chunks[c])-1].value > value
+	})
+}
+
+// chunkFrom returns the first chunk ending with a value greater than, or with inclusive equal to, the given one.
+func (n *numericIndex[K]) chunkFrom(value float64, inclusive bool) int {
+	if !inclusive {
+		return n.chunkAfter(value)
+	}
+	return sort.Search(len(n.chunks), func(c int) bool {
+		return n.chunks[c][len(n.chunks[c])-1].value >= value
+	})
+}
+
+// entries returns all the entries of the index in order.
+func (n *numericIndex[K]) entries() []numericEntry[K] {
+	entries := make([]numericEntry[K], 0)
+	for _, chunk := range n.chunks {
+		entries = append(entries, chunk...)
+	}
+	return entries
+}
+
+// find returns the ids of the documents with values in the range described by the filter.
+func (n *numericIndex[K]) find(filter *RangeFilter) map[K]struct{} {
+	lo, hi := math.Inf(-1), math.Inf(1)
+	loInclusive, hiInclusive := true, true
+
+	lower := func(value float64, inclusive bool) {
+		if value > lo || (value == lo && !inclusive) {
+			lo, loInclusive = value, inclusive
+		}
+	}
+	upper := func(value float64, inclusive bool) {
+		if value < hi || (value == hi && !inclusive) {
+			hi, hiInclusive = value, inclusive
+		}
+	}
+
+	if filter.Eq != nil {
+		lower(*filter.Eq, true)
+		upper(*filter.Eq, true)
+	}
+	if filter.Gt != nil {
+		lower(*filter.Gt, false)
+	}
+	if filter.Gte != nil {
+		lower(*filter.Gte, true)
+	}
+	if filter.Lt != nil {
+		upper(*filter.Lt, false)
+	}
+	if filter.Lte != nil {
+		upper(*filter.Lte, true)
+	}
+	if filter.Between != nil {
+		lower(filter.Between[0], true)
+		upper(filter.Between[1], true)
+	}
+
+	ids := make(map[K]struct{})
+	for c := n.chunkFrom(lo, loInclusive); c < len(n.chunks); c++ {
+		chunk := n.chunks[c]
+		i := sort.Search(len(chunk), func(i int) bool {
+			if loInclusive {
+				return chunk[i].value >= lo
+			}
+			return chunk[i].value > lo
+		})
+		for ; i < len(chunk); i++ {
+			if chunk[i].value > hi || chunk[i].value == hi && !hiInclusive {
+				return ids
+			}
+			ids[chunk[i].id] = struct{}{}
+		}
+	}
+
+	return ids
+}
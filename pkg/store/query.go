@@ -0,0 +1,632 @@
+package store
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/micpst/minisearch/pkg/tokenizer"
+)
+
+type query any
+
+type termQuery struct {
+	text string
+}
+
+type phraseQuery struct {
+	text string
+	slop int
+}
+
+type fieldQuery struct {
+	field string
+	query query
+}
+
+type booleanQuery struct {
+	must    []query
+	should  []query
+	mustNot []query
+}
+
+type notQuery struct {
+	query query
+}
+
+type requiredQuery struct {
+	query query
+}
+
+type queryContext struct {
+	properties []string
+	language   tokenizer.Language
+	scorer     Scorer
+	params     *SearchParams
+	terms      matchedTerms
+}
+
+type lexemeKind int
+
+const (
+	wordLexeme lexemeKind = iota
+	phraseLexeme
+	fieldLexeme
+	andLexeme
+	orLexeme
+	notLexeme
+	plusLexeme
+	minusLexeme
+	openLexeme
+	closeLexeme
+	endLexeme
+)
+
+type lexeme struct {
+	kind     lexemeKind
+	text     string
+	slop     int
+	position int
+}
+
+type queryParser struct {
+	query   string
+	lexemes []lexeme
+	current int
+}
+
+// parseQuery parses a boolean query into a tree of clauses. The grammar is:
+//
+//	query   = clauses
+//	clauses = conj { [ "OR" ] conj }
+//	conj    = unary { "AND" unary }
+//	unary   = ( "NOT" | "-" | "+" ) unary | primary
+//	primary = field ":" primary | "(" clauses ")" | phrase | word
+//	phrase  = '"' words '"' [ "~" slop ]
+//
+// Adjacent clauses are optional (OR-ed) unless prefixed with "+" (required) or "-" / "NOT" (prohibited).
+func parseQuery(text string) (query, error) {
+	lexemes, err := lexQuery(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := queryParser{
+		query:   text,
+		lexemes: lexemes,
+	}
+
+	q, err := p.parseClauses()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != endLexeme {
+		return nil, p.errorAt(next, "unexpected '"+next.text+"'")
+	}
+
+	return q, nil
+}
+
+func (p *queryParser) parseClauses() (query, error) {
+	q := &booleanQuery{}
+
+	for {
+		next := p.peek()
+		if next.kind == endLexeme || next.kind == closeLexeme {
+			break
+		}
+		if next.kind == orLexeme {
+			if len(q.must)+len(q.should)+len(q.mustNot) == 0 {
+				return nil, p.errorAt(next, "missing operand before 'OR'")
+			}
+			p.advance()
+		}
+
+		clause, err := p.parseConjunction()
+		if err != nil {
+			return nil, err
+		}
+
+		switch c := clause.(type) {
+		case *notQuery:
+			q.mustNot = append(q.mustNot, c.query)
+		case *requiredQuery:
+			q.must = append(q.must, c.query)
+		default:
+			q.should = append(q.should, c)
+		}
+	}
+
+	if len(q.must)+len(q.should)+len(q.mustNot) == 0 {
+		return nil, p.errorAt(p.peek(), "empty query")
+	}
+	if len(q.must) == 0 && len(q.mustNot) == 0 && len(q.should) == 1 {
+		return q.should[0], nil
+	}
+
+	return q, nil
+}
+
+func (p *queryParser) parseConjunction() (query, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != andLexeme {
+		return first, nil
+	}
+
+	q := &booleanQuery{}
+	operand := first
+
+	for {
+		switch o := operand.(type) {
+		case *notQuery:
+			q.mustNot = append(q.mustNot, o.query)
+		case *requiredQuery:
+			q.must = append(q.must, o.query)
+		default:
+			q.must = append(q.must, o)
+		}
+
+		if p.peek().kind != andLexeme {
+			break
+		}
+		p.advance()
+
+		if operand, err = p.parseUnary(); err != nil {
+			return nil, err
+		}
+	}
+
+	return q, nil
+}
+
+func (p *queryParser) parseUnary() (query, error) {
+	next := p.peek()
+
+	// nested modifiers are folded into one, a prohibition wins over a requirement
+	switch next.kind {
+	case notLexeme, minusLexeme:
+		p.advance()
+		q, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		switch q := q.(type) {
+		case *notQuery:
+			return q, nil
+		case *requiredQuery:
+			return &notQuery{query: q.query}, nil
+		}
+		return &notQuery{query: q}, nil
+	case plusLexeme:
+		p.advance()
+		q, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		switch q.(type) {
+		case *notQuery, *requiredQuery:
+			return q, nil
+		}
+		return &requiredQuery{query: q}, nil
+	default:
+		return p.parsePrimary()
+	}
+}
+
+func (p *queryParser) parsePrimary() (query, error) {
+	next := p.advance()
+
+	switch next.kind {
+	case wordLexeme:
+		return &termQuery{text: next.text}, nil
+	case phraseLexeme:
+		return &phraseQuery{text: next.text, slop: next.slop}, nil
+	case fieldLexeme:
+		q, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &fieldQuery{field: next.text, query: q}, nil
+	case openLexeme:
+		q, err := p.parseClauses()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != closeLexeme {
+			return nil, p.errorAt(closing, "missing ')'")
+		}
+		return q, nil
+	case endLexeme:
+		return nil, p.errorAt(next, "unexpected end of query")
+	default:
+		return nil, p.errorAt(next, "unexpected '"+next.text+"'")
+	}
+}
+
+func (p *queryParser) peek() lexeme {
+	return p.lexemes[p.current]
+}
+
+func (p *queryParser) advance() lexeme {
+	l := p.lexemes[p.current]
+	if l.kind != endLexeme {
+		p.current++
+	}
+	return l
+}
+
+func (p *queryParser) errorAt(l lexeme, reason string) error {
+	return &QuerySyntaxError{Query: p.query, Position: l.position, Reason: reason}
+}
+
+func lexQuery(text string) ([]lexeme, error) {
+	lexemes := make([]lexeme, 0)
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '"':
+			end := strings.IndexRune(text[i+size:], '"')
+			if end < 0 {
+				return nil, &QuerySyntaxError{Query: text, Position: i, Reason: "missing closing quote"}
+			}
+
+			l := lexeme{kind: phraseLexeme, text: text[i+size : i+size+end], position: i}
+			i += size + end + size
+
+			if i < len(text) && text[i] == '~' {
+				digits := i + 1
+				for digits < len(text) && text[digits] >= '0' && text[digits] <= '9' {
+					digits++
+				}
+				slop, err := strconv.Atoi(text[i+1 : digits])
+				if err != nil {
+					return nil, &QuerySyntaxError{Query: text, Position: i, Reason: "invalid phrase slop"}
+				}
+				l.slop = slop
+				i = digits
+			}
+
+			lexemes = append(lexemes, l)
+		case r == '(':
+			lexemes = append(lexemes, lexeme{kind: openLexeme, text: "(", position: i})
+			i += size
+		case r == ')':
+			lexemes = append(lexemes, lexeme{kind: closeLexeme, text: ")", position: i})
+			i += size
+		case (r == '+' || r == '-') && isQueryModifier(text[i+size:]):
+			kind := plusLexeme
+			if r == '-' {
+				kind = minusLexeme
+			}
+			lexemes = append(lexemes, lexeme{kind: kind, text: string(r), position: i})
+			i += size
+		default:
+			start := i
+			for i < len(text) && !isQuerySeparator(text[i:]) {
+				_, size = utf8.DecodeRuneInString(text[i:])
+				i += size
+			}
+
+			// a field qualifier applies to whatever follows the colon, unless it looks like a URL
+			if colon := strings.IndexByte(text[start:i], ':'); colon > 0 && isFieldName(text[start:start+colon]) &&
+				!strings.HasPrefix(text[start+colon+1:], "/") {
+				lexemes = append(lexemes, lexeme{kind: fieldLexeme, text: text[start : start+colon], position: start})
+				i = start + colon + 1
+				continue
+			}
+
+			l := lexeme{kind: wordLexeme, text: text[start:i], position: start}
+			switch l.text {
+			case "AND":
+				l.kind = andLexeme
+			case "OR":
+				l.kind = orLexeme
+			case "NOT":
+				l.kind = notLexeme
+			}
+			lexemes = append(lexemes, l)
+		}
+	}
+
+	return append(lexemes, lexeme{kind: endLexeme, position: len(text)}), nil
+}
+
+// isFieldName reports whether the text before a colon names a field, which starts with a letter
+// so that times and ratios like 10:30 or 16:9 stay terms.
+func isFieldName(text string) bool {
+	if r, _ := utf8.DecodeRuneInString(text); !unicode.IsLetter(r) {
+		return false
+	}
+	for _, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func isQueryModifier(rest string) bool {
+	r, size := utf8.DecodeRuneInString(rest)
+	return size > 0 && !unicode.IsSpace(r) && r != ')'
+}
+
+func isQuerySeparator(text string) bool {
+	r, _ := utf8.DecodeRuneInString(text)
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"'
+}
+
+// evaluate returns the scores of the documents matching the query. A nil result means
+// the query has no effect on the matches, e.g. it consists of stop words only.
+func (db *MemDB[S]) evaluate(q query, ctx *queryContext) (map[string]match, error) {
+	switch c := q.(type) {
+	case *termQuery:
+		return db.evaluateTerm(c, ctx)
+	case *phraseQuery:
+		return db.evaluatePhrase(c, ctx)
+	case *fieldQuery:
+		return db.evaluateField(c, ctx)
+	case *booleanQuery:
+		return db.evaluateBoolean(c, ctx)
+	default:
+		return nil, nil
+	}
+}
+
+func (db *MemDB[S]) evaluateTerm(q *termQuery, ctx *queryContext) (map[string]match, error) {
+	// the properties can have different analyzers, so every token is only looked up in those producing it
+	tokens := make([]string, 0)
+	tokenProperties := make(map[string][]string)
+	for _, prop := range ctx.properties {
+		propTokens := db.analyzer(prop, ctx.language).Tokenize(&tokenizer.AnalyzeParams{
+			Text:            q.text,
+			AllowDuplicates: false,
+		})
+		for _, token := range propTokens {
+			if _, ok := tokenProperties[token]; !ok {
+				tokens = append(tokens, token)
+			}
+			tokenProperties[token] = append(tokenProperties[token], prop)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	idMatches := make(map[string]match)
+
+	for _, token := range tokens {
+		termFields := make(map[string]map[string][]FieldStats)
+		termPenalties := make(map[string]float64)
+
+		for _, prop := range tokenProperties[token] {
+			results, err := db.index.find(&findParams{
+				term:      token,
+				property:  prop,
+				exact:     ctx.params.Exact,
+				tolerance: ctx.params.Tolerance,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, r := range results {
+				ctx.terms.add(prop, r.term)
+				if _, ok := termFields[r.term]; !ok {
+					termFields[r.term] = make(map[string][]FieldStats)
+					termPenalties[r.term] = penalty(token, r.term, r.distance, &ctx.params.Penalties)
+				}
+				db.collectFields(termFields[r.term], r.idStats, ctx)
+			}
+		}
+
+		tokenMatches := make(map[string]match)
+		for term, idFields := range termFields {
+			for id, fields := range idFields {
+				m := match{score: db.score(fields, ctx) * termPenalties[term]}
+				if ctx.params.Explain {
+					m.explanation = &Explanation{
+						Score:       m.score,
+						Description: "term",
+						Token:       token,
+						Term:        term,
+						Penalty:     termPenalties[term],
+						Fields:      fields,
+						Relevance:   &ctx.params.Relevance,
+					}
+				}
+				accumulate(tokenMatches, id, m, "token", ctx)
+			}
+		}
+
+		for id, m := range tokenMatches {
+			if m.explanation != nil {
+				m.explanation.Token = token
+			}
+			if len(tokens) == 1 {
+				idMatches[id] = m
+			} else {
+				accumulate(idMatches, id, m, "sum of", ctx)
+			}
+		}
+	}
+
+	return idMatches, nil
+}
+
+func (db *MemDB[S]) evaluatePhrase(q *phraseQuery, ctx *queryContext) (map[string]match, error) {
+	var phrase []string
+	idFields := make(map[string][]FieldStats)
+
+	for _, prop := range ctx.properties {
+		tokens := db.analyzer(prop, ctx.language).Tokenize(&tokenizer.AnalyzeParams{
+			Text:            q.text,
+			AllowDuplicates: true,
+		})
+		if len(tokens) == 0 {
+			continue
+		}
+		if phrase == nil {
+			phrase = tokens
+		}
+
+		idStats, err := db.index.phrase(&phraseParams{
+			tokens:   tokens,
+			property: prop,
+			slop:     q.slop,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(idStats) > 0 {
+			for _, token := range tokens {
+				ctx.terms.add(prop, token)
+			}
+		}
+		db.collectFields(idFields, idStats, ctx)
+	}
+
+	if phrase == nil {
+		return nil, nil
+	}
+
+	idMatches := make(map[string]match, len(idFields))
+	for id, fields := range idFields {
+		m := match{score: db.score(fields, ctx)}
+		if ctx.params.Explain {
+			m.explanation = &Explanation{
+				Score:       m.score,
+				Description: "phrase",
+				Token:       strings.Join(phrase, " "),
+				Fields:      fields,
+				Relevance:   &ctx.params.Relevance,
+			}
+		}
+		idMatches[id] = m
+	}
+
+	return idMatches, nil
+}
+
+func (db *MemDB[S]) evaluateField(q *fieldQuery, ctx *queryContext) (map[string]match, error) {
+	if _, ok := db.index.indexes[q.field]; !ok {
+		return nil, &WrongSearchPropertyType{Property: q.field}
+	}
+
+	fieldCtx := *ctx
+	fieldCtx.properties = []string{q.field}
+
+	return db.evaluate(q.query, &fieldCtx)
+}
+
+func (db *MemDB[S]) evaluateBoolean(q *booleanQuery, ctx *queryContext) (map[string]match, error) {
+	var idMatches map[string]match
+
+	for _, clause := range q.must {
+		matches, err := db.evaluate(clause, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if matches == nil {
+			continue
+		}
+
+		if idMatches == nil {
+			idMatches = make(map[string]match, len(matches))
+			for id, m := range matches {
+				accumulate(idMatches, id, m, "sum of", ctx)
+			}
+			continue
+		}
+		for id := range idMatches {
+			if m, ok := matches[id]; ok {
+				accumulate(idMatches, id, m, "sum of", ctx)
+			} else {
+				delete(idMatches, id)
+			}
+		}
+	}
+
+	required := idMatches != nil
+
+	for _, clause := range q.should {
+		matches, err := db.evaluate(clause, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if matches == nil {
+			continue
+		}
+
+		if idMatches == nil {
+			idMatches = make(map[string]match, len(matches))
+		}
+		for id, m := range matches {
+			if _, ok := idMatches[id]; ok || !required {
+				accumulate(idMatches, id, m, "sum of", ctx)
+			}
+		}
+	}
+
+	// prohibited terms are never highlighted
+	notCtx := *ctx
+	notCtx.terms = nil
+
+	for _, clause := range q.mustNot {
+		matches, err := db.evaluate(clause, &notCtx)
+		if err != nil {
+			return nil, err
+		}
+		if matches == nil {
+			continue
+		}
+
+		// a query with prohibited clauses only matches every other document
+		if idMatches == nil {
+			idMatches = make(map[string]match, len(db.documents))
+			for id := range db.documents {
+				accumulate(idMatches, id, match{}, "sum of", ctx)
+			}
+		}
+		for id := range matches {
+			delete(idMatches, id)
+		}
+	}
+
+	return idMatches, nil
+}
+
+func (db *MemDB[S]) collectFields(idFields map[string][]FieldStats, idStats map[string]FieldStats, ctx *queryContext) {
+	for id, stats := range idStats {
+		stats.Boost = db.index.boost(stats.Property, ctx.params.Boost)
+		idFields[id] = append(idFields[id], stats)
+	}
+}
+
+func (db *MemDB[S]) score(fields []FieldStats, ctx *queryContext) float64 {
+	return ctx.scorer.Score(&ScoreParams{
+		Fields:    fields,
+		DocsCount: len(db.documents),
+		Relevance: ctx.params.Relevance,
+	})
+}
+
+// penalty returns the factor applied to the score of an indexed term matched by the query token,
+// lowering it for every edit and for prefix-only matches.
+func penalty(token string, term string, distance int, params *PenaltyParams) float64 {
+	factor := math.Pow(1-params.Edit, float64(distance))
+	if distance == 0 && term != token {
+		factor *= 1 - params.Prefix
+	}
+	return factor
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	textProperty propertyKind = iota
+	keywordProperty
+	numberProperty
+	boolProperty
+	vectorProperty
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	vectorType = reflect.TypeOf([]float32{})
+)
+
+type propertyKind int
+
+type property struct {
+	name      string
+	kind      propertyKind
+	boost     float64
+	dims      int
+	metric    VectorMetric
+	analyzer  string
+	stem      *bool
+	stopWords *bool
+	language  bool
+}
+
+type indexTag struct {
+	name    string
+	options []indexTagOption
+}
+
+type indexTagOption struct {
+	key   string
+	value string
+}
+
+// parseIndexTag splits the `index` struct tag into the property name and its comma separated options,
+// e.g. `index:"title,boost=2"`. The options keep their order, and those without a value have an empty value.
+func parseIndexTag(tag string) indexTag {
+	parts := strings.Split(tag, ",")
+	t := indexTag{
+		name:    strings.TrimSpace(parts[0]),
+		options: make([]indexTagOption, 0, len(parts)-1),
+	}
+
+	for _, option := range parts[1:] {
+		key, value, _ := strings.Cut(option, "=")
+		t.options = append(t.options, indexTagOption{key: strings.TrimSpace(key), value: strings.TrimSpace(value)})
+	}
+
+	return t
+}
+
+func parseSchema(t reflect.Type, prefix ...string) (map[string]*property, error) {
+	properties := make(map[string]*property)
+	hasLanguage := false
+
+	for _, field := range reflect.VisibleFields(t) {
+		tag, ok := field.Tag.Lookup("index")
+		if !ok {
+			continue
+		}
+
+		parsedTag := parseIndexTag(tag)
+		propName := parsedTag.name
+		if len(prefix) == 1 {
+			propName = fmt.Sprintf("%s.%s", prefix[0], propName)
+		}
+
+		fieldType := elemType(field.Type)
+		if fieldType.Kind() == reflect.Struct && fieldType != timeType {
+			nested, err := parseSchema(fieldType, propName)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range nested {
+				properties[key] = value
+			}
+			continue
+		}
+
+		prop := &property{
+			name:  propName,
+			boost: 1,
+		}
+
+		switch fieldType.Kind() {
+		case reflect.String:
+			prop.kind = textProperty
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.Struct: // time.Time is indexed as a Unix timestamp
+			prop.kind = numberProperty
+		case reflect.Bool:
+			prop.kind = boolProperty
+		default:
+			return nil, &UnsupportedFieldTypeError{Field: field.Name, Type: field.Type.String()}
+		}
+
+		for _, option := range parsedTag.options {
+			key, value := option.key, option.value
+			switch key {
+			case "boost":
+				boost, err := strconv.ParseFloat(value, 64)
+				if err != nil || boost < 0 {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "boost must be a non-negative number"}
+				}
+				prop.boost = boost
+			case "keyword":
+				// the field type is checked rather than the kind, which the language option also sets
+				if fieldType.Kind() != reflect.String {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "keyword is only supported on string fields"}
+				}
+				prop.kind = keywordProperty
+			case "analyzer":
+				if value == "" {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "analyzer requires a name"}
+				}
+				prop.analyzer = value
+			case "stem", "stopwords":
+				enabled, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: fmt.Sprintf("%s must be a boolean", key)}
+				}
+				if key == "stem" {
+					prop.stem = &enabled
+				} else {
+					prop.stopWords = &enabled
+				}
+			case "language":
+				if field.Type.Kind() != reflect.String {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "language is only supported on string fields"}
+				}
+				// the language of a document is only read from its top-level fields
+				if len(prefix) == 1 {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "language is not supported on nested fields"}
+				}
+				if hasLanguage {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "only one field can hold the language"}
+				}
+				hasLanguage = true
+				prop.kind = keywordProperty
+				prop.language = true
+			case "vector":
+				if field.Type != vectorType {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "vector is only supported on []float32 fields"}
+				}
+				prop.kind = vectorProperty
+			case "dims":
+				dims, err := strconv.Atoi(value)
+				if err != nil || dims <= 0 {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "dims must be a positive integer"}
+				}
+				prop.dims = dims
+			case "metric":
+				metric := VectorMetric(value)
+				if metric != COSINE && metric != DOT && metric != L2 {
+					return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: fmt.Sprintf("unknown metric '%s'", value)}
+				}
+				prop.metric = metric
+			default:
+				return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: fmt.Sprintf("unknown option '%s'", key)}
+			}
+		}
+
+		if prop.kind == vectorProperty {
+			if prop.dims == 0 {
+				return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "vector requires dims"}
+			}
+			if prop.metric == "" {
+				prop.metric = COSINE
+			}
+		} else if prop.dims != 0 || prop.metric != "" {
+			return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "dims and metric require the vector option"}
+		}
+
+		if prop.kind != textProperty && (prop.analyzer != "" || prop.stem != nil || prop.stopWords != nil) {
+			return nil, &InvalidIndexTagError{Field: field.Name, Tag: tag, Reason: "analyzer, stem and stopwords are only supported on text fields"}
+		}
+
+		properties[propName] = prop
+	}
+
+	return properties, nil
+}
+
+// elemType returns the type of the values held by a field, looking through pointers and slices.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
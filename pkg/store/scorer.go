@@ -0,0 +1,124 @@
+package store
+
+import (
+	"math"
+
+	"github.com/micpst/minisearch/pkg/lib"
+)
+
+const (
+	BM25     Scoring = "bm25"
+	BM25PLUS Scoring = "bm25+"
+	BM25F    Scoring = "bm25f"
+	TFIDF    Scoring = "tfidf"
+	CONSTANT Scoring = "constant"
+)
+
+var builtinScorers = map[Scoring]Scorer{
+	BM25:     &BM25Scorer{},
+	BM25PLUS: &BM25PlusScorer{},
+	BM25F:    &BM25FScorer{},
+	TFIDF:    &TFIDFScorer{},
+	CONSTANT: &ConstantScorer{},
+}
+
+type Scoring string
+
+// Scorer computes the relevance of a single term (or phrase) in a document
+// given its statistics in every property of the document it was found in.
+type Scorer interface {
+	Score(params *ScoreParams) float64
+}
+
+type ScoreParams struct {
+	Fields    []FieldStats
+	DocsCount int
+	Relevance BM25Params
+}
+
+type FieldStats struct {
+	Property          string
+	TermFrequency     float64
+	DocumentFrequency int
+	FieldLength       int
+	AvgFieldLength    float64
+	Boost             float64
+}
+
+type BM25Scorer struct{}
+
+type BM25PlusScorer struct{}
+
+type BM25FScorer struct{}
+
+type TFIDFScorer struct{}
+
+type ConstantScorer struct{}
+
+func (s *BM25Scorer) Score(params *ScoreParams) float64 {
+	score := 0.0
+	for _, field := range params.Fields {
+		score += field.Boost * lib.BM25(
+			field.TermFrequency,
+			field.DocumentFrequency,
+			field.FieldLength,
+			field.AvgFieldLength,
+			params.DocsCount,
+			params.Relevance.K,
+			params.Relevance.B,
+			0,
+		)
+	}
+	return score
+}
+
+func (s *BM25PlusScorer) Score(params *ScoreParams) float64 {
+	score := 0.0
+	for _, field := range params.Fields {
+		score += field.Boost * lib.BM25(
+			field.TermFrequency,
+			field.DocumentFrequency,
+			field.FieldLength,
+			field.AvgFieldLength,
+			params.DocsCount,
+			params.Relevance.K,
+			params.Relevance.B,
+			params.Relevance.D,
+		)
+	}
+	return score
+}
+
+// Score combines the length normalized term frequencies of all the fields before the saturation,
+// so a term repeated across many fields does not score higher than a term repeated in one field.
+func (s *BM25FScorer) Score(params *ScoreParams) float64 {
+	tf := 0.0
+	matchingDocsCount := 0
+
+	for _, field := range params.Fields {
+		norm := 1 - params.Relevance.B + params.Relevance.B*float64(field.FieldLength)/field.AvgFieldLength
+		tf += field.Boost * field.TermFrequency / norm
+		if field.DocumentFrequency > matchingDocsCount {
+			matchingDocsCount = field.DocumentFrequency
+		}
+	}
+
+	idf := math.Log(1 + (float64(params.DocsCount-matchingDocsCount)+0.5)/(float64(matchingDocsCount)+0.5))
+	return idf * tf * (params.Relevance.K + 1) / (tf + params.Relevance.K)
+}
+
+func (s *TFIDFScorer) Score(params *ScoreParams) float64 {
+	score := 0.0
+	for _, field := range params.Fields {
+		score += field.Boost * lib.TFIDF(field.TermFrequency, field.DocumentFrequency, params.DocsCount)
+	}
+	return score
+}
+
+func (s *ConstantScorer) Score(params *ScoreParams) float64 {
+	score := 0.0
+	for _, field := range params.Fields {
+		score += field.Boost
+	}
+	return score
+}
@@ -0,0 +1,264 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"io"
+	"maps"
+
+	"github.com/micpst/minisearch/pkg/hnsw"
+	"github.com/micpst/minisearch/pkg/radix"
+	"github.com/micpst/minisearch/pkg/tokenizer"
+)
+
+// snapshotVersion must be bumped whenever the layout of the encoded snapshot changes.
+const snapshotVersion uint32 = 5
+
+var snapshotMagic = [4]byte{'M', 'S', 'D', 'B'}
+
+type snapshotHeader struct {
+	Magic   [4]byte
+	Version uint32
+}
+
+type snapshot[S Schema] struct {
+	Documents map[string]S
+	Languages map[string]tokenizer.Language
+	Analysis  analysisSnapshot
+	Index     indexSnapshot[string]
+}
+
+// analysisSnapshot records how the texts of the index were analyzed: the analysis of every text property
+// and the fingerprints of the languages used.
+type analysisSnapshot struct {
+	Properties map[string]propertyAnalysis
+	Languages  map[tokenizer.Language]uint64
+}
+
+type indexSnapshot[K recordId] struct {
+	Properties map[string]propertySnapshot[K]
+	Graphs     map[string]*hnsw.Snapshot[K]
+}
+
+type propertySnapshot[K recordId] struct {
+	Postings         []postingSnapshot[K]
+	AvgFieldLength   float64
+	FieldLengths     map[K]int
+	TokenOccurrences map[string]int
+}
+
+type postingSnapshot[K recordId] struct {
+	Term            string
+	Ids             []K
+	TermFrequencies []float64
+	Positions       [][]int
+}
+
+func (db *MemDB[S]) Save(w io.Writer) error {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	return db.save(w)
+}
+
+func (db *MemDB[S]) save(w io.Writer) error {
+	header := snapshotHeader{
+		Magic:   snapshotMagic,
+		Version: snapshotVersion,
+	}
+	if err := binary.Write(w, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+
+	analysis, err := db.analysis(db.languages)
+	if err != nil {
+		return err
+	}
+
+	return gob.NewEncoder(w).Encode(&snapshot[S]{
+		Documents: db.documents,
+		Languages: db.languages,
+		Analysis:  analysis,
+		Index:     db.index.snapshot(),
+	})
+}
+
+func Load[S Schema](r io.Reader, c *Config) (*MemDB[S], error) {
+	header := snapshotHeader{}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, &InvalidSnapshotError{Reason: err.Error()}
+	}
+	if header.Magic != snapshotMagic {
+		return nil, &InvalidSnapshotError{Reason: "unknown file format"}
+	}
+	if header.Version != snapshotVersion {
+		return nil, &SnapshotVersionError{Version: header.Version}
+	}
+
+	snap := snapshot[S]{}
+	if err := gob.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, &InvalidSnapshotError{Reason: err.Error()}
+	}
+
+	db, err := New[S](c)
+	if err != nil {
+		return nil, err
+	}
+	if len(snap.Languages) != len(snap.Documents) {
+		return nil, &InvalidSnapshotError{Reason: "document languages do not match the documents"}
+	}
+
+	analysis, err := db.analysis(snap.Languages)
+	if err != nil {
+		return nil, &InvalidSnapshotError{Reason: err.Error()}
+	}
+
+	// an index analyzed with other settings or language rules is rebuilt from the documents
+	if !analysis.equal(&snap.Analysis) {
+		for id, document := range snap.Documents {
+			db.insert(id, document, snap.Languages[id])
+		}
+		return db, nil
+	}
+
+	if err := db.index.restore(&snap.Index); err != nil {
+		return nil, err
+	}
+	if snap.Documents != nil {
+		db.documents = snap.Documents
+	}
+	if snap.Languages != nil {
+		db.languages = snap.Languages
+	}
+
+	// the numeric, keyword and exact vector indexes are rebuilt in bulk, so they are not part of the snapshot
+	db.index.loadValues(db.documents)
+
+	return db, nil
+}
+
+// analysis returns the current analysis of the text properties and the fingerprints of the given
+// document languages and of the languages selected by name as analyzers.
+func (db *MemDB[S]) analysis(languages map[string]tokenizer.Language) (analysisSnapshot, error) {
+	analysis := analysisSnapshot{
+		Properties: make(map[string]propertyAnalysis, len(db.index.indexes)),
+		Languages:  make(map[tokenizer.Language]uint64),
+	}
+
+	used := make(map[tokenizer.Language]struct{})
+	for _, language := range languages {
+		used[language] = struct{}{}
+	}
+	for prop := range db.index.indexes {
+		analysis.Properties[prop] = db.propertyAnalysis(prop)
+		if name := analysis.Properties[prop].Analyzer; name != "" {
+			if _, ok := db.analyzers[name]; !ok {
+				used[tokenizer.Language(name)] = struct{}{}
+			}
+		}
+	}
+
+	for language := range used {
+		fingerprint, err := tokenizer.Fingerprint(language)
+		if err != nil {
+			return analysisSnapshot{}, err
+		}
+		analysis.Languages[language] = fingerprint
+	}
+
+	return analysis, nil
+}
+
+func (a *analysisSnapshot) equal(other *analysisSnapshot) bool {
+	return maps.Equal(a.Properties, other.Properties) && maps.Equal(a.Languages, other.Languages)
+}
+
+func (idx *index[K, S]) snapshot() indexSnapshot[K] {
+	snap := indexSnapshot[K]{
+		Properties: make(map[string]propertySnapshot[K], len(idx.indexes)),
+		Graphs:     make(map[string]*hnsw.Snapshot[K]),
+	}
+
+	for propName, vectors := range idx.vectors {
+		if vectors.graph != nil {
+			snap.Graphs[propName] = vectors.graph.Snapshot()
+		}
+	}
+
+	for propName, index := range idx.indexes {
+		postings := make([]postingSnapshot[K], 0, index.Len())
+		index.Walk(func(word string, data map[K]recordInfo) {
+			posting := postingSnapshot[K]{
+				Term:            word,
+				Ids:             make([]K, 0, len(data)),
+				TermFrequencies: make([]float64, 0, len(data)),
+				Positions:       make([][]int, 0, len(data)),
+			}
+			for id, info := range data {
+				posting.Ids = append(posting.Ids, id)
+				posting.TermFrequencies = append(posting.TermFrequencies, info.termFrequency)
+				posting.Positions = append(posting.Positions, info.positions)
+			}
+			postings = append(postings, posting)
+		})
+
+		snap.Properties[propName] = propertySnapshot[K]{
+			Postings:         postings,
+			AvgFieldLength:   idx.avgFieldLength[propName],
+			FieldLengths:     idx.fieldLengths[propName],
+			TokenOccurrences: idx.tokenOccurrences[propName],
+		}
+	}
+
+	return snap
+}
+
+func (idx *index[K, S]) restore(snap *indexSnapshot[K]) error {
+	if len(snap.Properties) != len(idx.indexes) {
+		return &InvalidSnapshotError{Reason: "indexed properties do not match the schema"}
+	}
+
+	for propName, prop := range snap.Properties {
+		index, ok := idx.indexes[propName]
+		if !ok {
+			return &InvalidSnapshotError{Reason: "unknown property '" + propName + "'"}
+		}
+
+		for _, posting := range prop.Postings {
+			if len(posting.TermFrequencies) != len(posting.Ids) || len(posting.Positions) != len(posting.Ids) {
+				return &InvalidSnapshotError{Reason: "malformed posting for term '" + posting.Term + "'"}
+			}
+			for i, id := range posting.Ids {
+				index.Insert(&radix.InsertParams[K, recordInfo]{
+					Id:   id,
+					Word: posting.Term,
+					Data: recordInfo{termFrequency: posting.TermFrequencies[i], positions: posting.Positions[i]},
+				})
+			}
+		}
+
+		idx.avgFieldLength[propName] = prop.AvgFieldLength
+		if prop.FieldLengths != nil {
+			idx.fieldLengths[propName] = prop.FieldLengths
+		}
+		if prop.TokenOccurrences != nil {
+			idx.tokenOccurrences[propName] = prop.TokenOccurrences
+		}
+	}
+
+	// graphs missing from the snapshot, e.g. saved without HNSW, are rebuilt from the documents
+	for propName, graph := range snap.Graphs {
+		vectors, ok := idx.vectors[propName]
+		if !ok {
+			return &InvalidSnapshotError{Reason: "unknown vector property '" + propName + "'"}
+		}
+		if vectors.graph == nil {
+			continue
+		}
+		if err := vectors.graph.Restore(graph); err != nil {
+			return &InvalidSnapshotError{Reason: err.Error()}
+		}
+	}
+
+	return nil
+}
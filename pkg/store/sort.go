@@ -0,0 +1,141 @@
+package store
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+)
+
+const (
+	ASC  SortOrder = "asc"
+	DESC SortOrder = "desc"
+)
+
+// Pseudo-fields sorting the hits by their relevance and id.
+const (
+	ScoreField = "_score"
+	IdField    = "_id"
+)
+
+type SortOrder string
+
+// SortParams order the hits by a numeric, keyword or bool property, or by one of the pseudo-fields.
+// The order defaults to descending for ScoreField and to ascending otherwise.
+type SortParams struct {
+	Field string
+	Order SortOrder
+}
+
+func (idx *index[K, S]) validateSort(params []SortParams) error {
+	for _, p := range params {
+		if p.Order != "" && p.Order != ASC && p.Order != DESC {
+			return &SortOrderNotSupportedError{Order: p.Order}
+		}
+		if p.Field == ScoreField || p.Field == IdField {
+			continue
+		}
+		_, isNumber := idx.numbers[p.Field]
+		_, isKeyword := idx.keywords[p.Field]
+		if !isNumber && !isKeyword {
+			return &WrongSortPropertyType{Property: p.Field}
+		}
+	}
+	return nil
+}
+
+// sort orders the hits by the given fields, breaking the remaining ties by id.
+// Hits without a value of a property go last in both orders.
+func (db *MemDB[S]) sort(hits SearchHits[S], params []SortParams) {
+	keys := make(map[string][]any, len(hits))
+	for i := range hits {
+		hitKeys := make([]any, len(params))
+		for k := range params {
+			hitKeys[k] = db.sortKey(&hits[i], &params[k])
+		}
+		keys[hits[i].Id] = hitKeys
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := keys[hits[i].Id], keys[hits[j].Id]
+		for k := range params {
+			if a[k] == nil || b[k] == nil {
+				if (a[k] == nil) != (b[k] == nil) {
+					return b[k] == nil
+				}
+				continue
+			}
+
+			c := compareValues(a[k], b[k])
+			if c == 0 {
+				continue
+			}
+			if params[k].descending() {
+				return c > 0
+			}
+			return c < 0
+		}
+		return hits[i].Id < hits[j].Id
+	})
+}
+
+// sortKey returns the value the hit is sorted by. Multi-valued properties are sorted
+// by their lowest value in ascending order and by their highest value in descending order.
+func (db *MemDB[S]) sortKey(hit *SearchHit[S], params *SortParams) any {
+	switch params.Field {
+	case ScoreField:
+		return hit.Score
+	case IdField:
+		return hit.Id
+	}
+
+	var hitValues []any
+	if numbers, ok := db.index.numbers[params.Field]; ok {
+		for _, value := range numbers.values[hit.Id] {
+			hitValues = append(hitValues, value)
+		}
+	} else {
+		hitValues = db.index.keywords[params.Field].values[hit.Id]
+	}
+
+	var key any
+	for _, value := range hitValues {
+		if key == nil {
+			key = value
+			continue
+		}
+		if c := compareValues(value, key); params.descending() && c > 0 || !params.descending() && c < 0 {
+			key = value
+		}
+	}
+
+	return key
+}
+
+func (p *SortParams) descending() bool {
+	return p.Order == DESC || p.Order == "" && p.Field == ScoreField
+}
+
+func compareValues(a any, b any) int {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			return cmp.Compare(a, b)
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return cmp.Compare(a, b)
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0
+			case b:
+				return -1
+			default:
+				return 1
+			}
+		}
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,190 @@
+package store
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/radix"
+	"github.com/micpst/minisearch/pkg/tokenizer"
+)
+
+const (
+	defaultSpellingDistance  = 2
+	defaultSpellingFrequency = 1
+)
+
+// SpellingParams look for corrections of the query words whose document frequency, summed over the searched
+// properties, is below MinFrequency. The candidates are the more frequent indexed terms within MaxDistance edits,
+// preferring the closest and then the most frequent ones.
+// With AutoCorrect, a query without any hit is run again with the correction.
+type SpellingParams struct {
+	MaxDistance  int
+	MinFrequency int
+	AutoCorrect  bool
+}
+
+type correction struct {
+	start   int
+	end     int
+	surface string
+}
+
+// correct returns the query with its rare words replaced by the best indexed terms, or an empty string
+// if none of the words could be corrected.
+func (db *MemDB[S]) correct(text string, properties []string, language tokenizer.Language, params *SpellingParams) string {
+	maxDistance := params.MaxDistance
+	if maxDistance <= 0 {
+		maxDistance = defaultSpellingDistance
+	}
+	minFrequency := params.MinFrequency
+	if minFrequency <= 0 {
+		minFrequency = defaultSpellingFrequency
+	}
+
+	lexemes, err := lexQuery(text)
+	if err != nil {
+		return ""
+	}
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	corrections := make([]correction, 0)
+	fieldProperties := properties
+
+	for _, l := range lexemes {
+		if l.kind == fieldLexeme {
+			if _, ok := db.index.indexes[l.text]; ok {
+				fieldProperties = []string{l.text}
+			}
+			continue
+		}
+		if l.kind != wordLexeme && l.kind != phraseLexeme {
+			continue
+		}
+
+		// the text of a phrase starts after its opening quote
+		offset := l.position
+		if l.kind == phraseLexeme {
+			offset++
+		}
+
+		// the properties can have different analyzers, so the tokens of every word are grouped by property
+		spans := make([][2]int, 0)
+		spanTokens := make(map[[2]int]map[string]string)
+		for _, prop := range fieldProperties {
+			tokens := db.analyzer(prop, language).TokenizeWithOffsets(&tokenizer.AnalyzeParams{
+				Text:            l.text,
+				AllowDuplicates: true,
+			})
+			for _, token := range tokens {
+				span := [2]int{offset + token.Start, offset + token.End}
+				if _, ok := spanTokens[span]; !ok {
+					spans = append(spans, span)
+					spanTokens[span] = make(map[string]string)
+				}
+				spanTokens[span][prop] = token.Value
+			}
+		}
+		sort.Slice(spans, func(i, j int) bool {
+			return spans[i][0] < spans[j][0]
+		})
+
+		end := 0
+		for _, span := range spans {
+			// a word is corrected once, even if another analyzer splits it differently
+			if span[0] < end {
+				continue
+			}
+
+			term, props, ok := db.closestTerm(spanTokens[span], maxDistance, minFrequency)
+			if !ok {
+				continue
+			}
+
+			records := make([]map[string]recordInfo, 0, len(props))
+			for _, prop := range props {
+				for _, r := range db.index.indexes[prop].Find(&radix.FindParams{Term: term, Exact: true}) {
+					records = append(records, r.Data)
+				}
+			}
+			corrections = append(corrections, correction{
+				start:   span[0],
+				end:     span[1],
+				surface: db.surface(term, records, nil, props),
+			})
+			end = span[1]
+		}
+
+		fieldProperties = properties
+	}
+
+	if len(corrections) == 0 {
+		return ""
+	}
+
+	b := strings.Builder{}
+	pos := 0
+	for _, c := range corrections {
+		b.WriteString(text[pos:c.start])
+		b.WriteString(c.surface)
+		pos = c.end
+	}
+	b.WriteString(text[pos:])
+
+	return b.String()
+}
+
+// closestTerm finds the indexed term replacing the tokens of a word, given by property, whose document
+// frequency is below minFrequency. It also returns the properties in which the term is indexed.
+func (db *MemDB[S]) closestTerm(tokens map[string]string, maxDistance int, minFrequency int) (string, []string, bool) {
+	frequency := 0
+	for prop, token := range tokens {
+		frequency += db.index.tokenOccurrences[prop][token]
+	}
+	if frequency >= minFrequency {
+		return "", nil, false
+	}
+
+	type candidate struct {
+		distance   int
+		frequency  int
+		properties []string
+	}
+
+	candidates := make(map[string]*candidate)
+	for prop, token := range tokens {
+		results := db.index.indexes[prop].FindSimilar(&radix.FindParams{Term: token, Tolerance: maxDistance})
+		for _, r := range results {
+			if r.Term == token {
+				continue
+			}
+
+			c, ok := candidates[r.Term]
+			if !ok {
+				c = &candidate{distance: r.Distance}
+				candidates[r.Term] = c
+			}
+			c.distance = min(c.distance, r.Distance)
+			c.frequency += db.index.tokenOccurrences[prop][r.Term]
+			c.properties = append(c.properties, prop)
+		}
+	}
+
+	var best string
+	var bestCandidate *candidate
+	for term, c := range candidates {
+		if c.frequency <= frequency {
+			continue
+		}
+		if bestCandidate == nil || c.distance < bestCandidate.distance ||
+			c.distance == bestCandidate.distance && (c.frequency > bestCandidate.frequency || c.frequency == bestCandidate.frequency && term < best) {
+			best, bestCandidate = term, c
+		}
+	}
+	if bestCandidate == nil {
+		return "", nil, false
+	}
+
+	return best, bestCandidate.properties, true
+}
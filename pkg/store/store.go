@@ -1,20 +1,29 @@
 package store
 
 import (
+	"maps"
 	"math"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
+	"github.com/micpst/minisearch/pkg/hnsw"
 	"github.com/micpst/minisearch/pkg/lib"
 	"github.com/micpst/minisearch/pkg/tokenizer"
+	"github.com/micpst/minisearch/pkg/wal"
 )
 
 type Schema any
 
+// Record is an indexed document. Language is the language it is indexed with and Confidence
+// the probability of that language if it was detected.
 type Record[S Schema] struct {
-	Id   string
-	Data S
+	Id         string
+	Data       S
+	Language   tokenizer.Language
+	Confidence float64
 }
 
 type InsertParams[S Schema] struct {
@@ -35,19 +44,47 @@ type UpdateParams[S Schema] struct {
 }
 
 type DeleteParams[S Schema] struct {
-	Id       string
-	Language tokenizer.Language
+	Id string
 }
 
 type SearchParams struct {
 	Query      string
 	Properties []string
+	Boost      map[string]float64
 	Exact      bool
 	Tolerance  int
 	Relevance  BM25Params
+	Penalties  PenaltyParams
+	Scoring    Scoring
 	Offset     int
 	Limit      int
 	Language   tokenizer.Language
+	Explain    bool
+	Filters    Filters
+	Facets     map[string]FacetParams
+	Sort       []SortParams
+	Vector     *VectorParams
+	Hybrid     HybridParams
+	Highlight  *HighlightParams
+	Spelling   *SpellingParams
+}
+
+// Filters restrict the search results without affecting their scores. All the filters must match.
+type Filters struct {
+	Range map[string]RangeFilter
+	Term  map[string]any
+	Terms map[string][]any
+}
+
+// RangeFilter matches documents with a numeric property in the given range.
+// All the set conditions must hold, Between bounds are inclusive.
+type RangeFilter struct {
+	Eq      *float64
+	Gt      *float64
+	Gte     *float64
+	Lt      *float64
+	Lte     *float64
+	Between *[2]float64
 }
 
 type BM25Params struct {
@@ -56,15 +93,33 @@ type BM25Params struct {
 	D float64
 }
 
+// PenaltyParams lower the score of terms that only approximately match a query token.
+// A match is multiplied by (1 - Edit) for every edit and by (1 - Prefix) if it only shares a prefix.
+type PenaltyParams struct {
+	Edit   float64
+	Prefix float64
+}
+
+// SearchResult holds the hits of a search. DidYouMean is the spelling correction of the query, if any,
+// and Corrected reports that the hits are those of the correction. Language is the language the query
+// is analyzed in and Confidence the probability of that language if it was detected.
 type SearchResult[S Schema] struct {
-	Hits  SearchHits[S]
-	Count int
+	Hits       SearchHits[S]
+	Count      int
+	Facets     map[string]FacetResult
+	DidYouMean string
+	Corrected  bool
+	Language   tokenizer.Language
+	Confidence float64
 }
 
 type SearchHit[S Schema] struct {
-	Id    string
-	Data  S
-	Score float64
+	Id          string
+	Data        S
+	Score       float64
+	Explanation *Explanation
+	Hybrid      *HybridScores
+	Highlights  map[string][]string
 }
 
 type SearchHits[S Schema] []SearchHit[S]
@@ -73,11 +128,26 @@ func (r SearchHits[S]) Len() int { return len(r) }
 
 func (r SearchHits[S]) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
 
-func (r SearchHits[S]) Less(i, j int) bool { return r[i].Score > r[j].Score }
+// Less orders the hits by score, breaking ties by id so that the pagination is stable.
+func (r SearchHits[S]) Less(i, j int) bool {
+	if r[i].Score != r[j].Score {
+		return r[i].Score > r[j].Score
+	}
+	return r[i].Id < r[j].Id
+}
 
 type Config struct {
 	DefaultLanguage tokenizer.Language
 	TokenizerConfig *tokenizer.Config
+	// Analyzers register custom analyzers by name. DefaultAnalyzer selects the one analyzing the text
+	// properties without an analyzer in their tag, in place of the built-in analyzer of the document language.
+	Analyzers       map[string]*tokenizer.Analyzer
+	DefaultAnalyzer string
+	DefaultScoring  Scoring
+	Scorers         map[Scoring]Scorer
+	Log             *wal.Log
+	// HNSW indexes the vector properties in graphs for approximate search, they are scanned exactly if nil.
+	HNSW *hnsw.Config
 }
 
 type MemDB[S Schema] struct {
@@ -85,29 +155,79 @@ type MemDB[S Schema] struct {
 	documents       map[string]S
 	index           *index[string, S]
 	defaultLanguage tokenizer.Language
+	languages       map[string]tokenizer.Language
 	tokenizerConfig *tokenizer.Config
+	analyzers       map[string]*tokenizer.Analyzer
+	defaultAnalyzer string
+	defaultScoring  Scoring
+	scorers         map[Scoring]Scorer
+	analyzerCache   atomic.Pointer[analyzerCache]
+	log             *wal.Log
 }
 
-func New[S Schema](c *Config) *MemDB[S] {
-	return &MemDB[S]{
+func New[S Schema](c *Config) (*MemDB[S], error) {
+	idx, err := newIndex[string, S](c.HNSW)
+	if err != nil {
+		return nil, err
+	}
+
+	scorers := make(map[Scoring]Scorer, len(builtinScorers)+len(c.Scorers))
+	maps.Copy(scorers, builtinScorers)
+	maps.Copy(scorers, c.Scorers)
+
+	defaultScoring := c.DefaultScoring
+	if defaultScoring == "" {
+		defaultScoring = BM25PLUS
+	} else if _, ok := scorers[defaultScoring]; !ok {
+		return nil, &ScoringNotSupportedError{Scoring: defaultScoring}
+	}
+
+	analyzers := make(map[string]*tokenizer.Analyzer, len(builtinAnalyzers)+len(c.Analyzers))
+	maps.Copy(analyzers, builtinAnalyzers)
+	maps.Copy(analyzers, c.Analyzers)
+
+	if !isSupportedAnalyzer(c.DefaultAnalyzer, analyzers) {
+		return nil, &AnalyzerNotSupportedError{Analyzer: c.DefaultAnalyzer}
+	}
+	for _, prop := range idx.properties {
+		if !isSupportedAnalyzer(prop.analyzer, analyzers) {
+			return nil, &AnalyzerNotSupportedError{Analyzer: prop.analyzer}
+		}
+	}
+	if !tokenizer.IsSupportedLanguage(c.DefaultLanguage) {
+		return nil, &tokenizer.LanguageNotSupportedError{Language: c.DefaultLanguage}
+	}
+
+	db := &MemDB[S]{
 		documents:       make(map[string]S),
-		index:           newIndex[string, S](),
+		index:           idx,
+		languages:       make(map[string]tokenizer.Language),
 		defaultLanguage: c.DefaultLanguage,
 		tokenizerConfig: c.TokenizerConfig,
+		analyzers:       analyzers,
+		defaultAnalyzer: c.DefaultAnalyzer,
+		defaultScoring:  defaultScoring,
+		scorers:         scorers,
+		log:             c.Log,
 	}
+	db.analyzerCache.Store(db.newAnalyzerCache())
+
+	return db, nil
 }
 
 func (db *MemDB[S]) Insert(params *InsertParams[S]) (Record[S], error) {
 	id := uuid.NewString()
 
-	language := params.Language
-	if language == "" {
-		language = db.defaultLanguage
+	if !isSupportedLanguage(params.Language) {
+		return Record[S]{}, &tokenizer.LanguageNotSupportedError{Language: params.Language}
+	}
 
-	} else if !tokenizer.IsSupportedLanguage(language) {
-		return Record[S]{}, &tokenizer.LanguageNotSupportedError{Language: language}
+	if err := db.index.validate(params.Document); err != nil {
+		return Record[S]{}, err
 	}
 
+	language, confidence := db.documentLanguage(params.Document, params.Language, db.defaultLanguage)
+
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
@@ -115,17 +235,18 @@ func (db *MemDB[S]) Insert(params *InsertParams[S]) (Record[S], error) {
 		return Record[S]{}, &DocumentAlreadyExistsError{Id: id}
 	}
 
-	db.documents[id] = params.Document
+	if err := db.writeLog(&logRecord[S]{
+		Operation: insertOperation,
+		Id:        id,
+		Document:  params.Document,
+		Language:  language,
+	}); err != nil {
+		return Record[S]{}, err
+	}
 
-	db.index.insert(&indexParams[string, S]{
-		id:              id,
-		document:        params.Document,
-		docsCount:       len(db.documents),
-		language:        language,
-		tokenizerConfig: db.tokenizerConfig,
-	})
+	db.insert(id, params.Document, language)
 
-	return Record[S]{Id: id, Data: params.Document}, nil
+	return Record[S]{Id: id, Data: params.Document, Language: language, Confidence: confidence}, nil
 }
 
 func (db *MemDB[S]) InsertBatch(params *InsertBatchParams[S]) []error {
@@ -172,74 +293,90 @@ func (db *MemDB[S]) InsertBatch(params *InsertBatchParams[S]) []error {
 }
 
 func (db *MemDB[S]) Update(params *UpdateParams[S]) (Record[S], error) {
-	language := params.Language
-	if language == "" {
-		language = db.defaultLanguage
+	if !isSupportedLanguage(params.Language) {
+		return Record[S]{}, &tokenizer.LanguageNotSupportedError{Language: params.Language}
+	}
 
-	} else if !tokenizer.IsSupportedLanguage(language) {
-		return Record[S]{}, &tokenizer.LanguageNotSupportedError{Language: language}
+	if err := db.index.validate(params.Document); err != nil {
+		return Record[S]{}, err
 	}
 
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
-	oldDocument, ok := db.documents[params.Id]
-	if !ok {
+	if _, ok := db.documents[params.Id]; !ok {
 		return Record[S]{}, &DocumentNotFoundError{Id: params.Id}
 	}
 
-	db.documents[params.Id] = params.Document
+	// without a new language, the document keeps the one it was indexed with
+	language, confidence := db.documentLanguage(params.Document, params.Language, db.languages[params.Id])
 
-	db.index.insert(&indexParams[string, S]{
-		id:              params.Id,
-		document:        params.Document,
-		docsCount:       len(db.documents),
-		language:        language,
-		tokenizerConfig: db.tokenizerConfig,
-	})
-	db.index.delete(&indexParams[string, S]{
-		id:              params.Id,
-		document:        oldDocument,
-		docsCount:       len(db.documents),
-		language:        language,
-		tokenizerConfig: db.tokenizerConfig,
-	})
+	if err := db.writeLog(&logRecord[S]{
+		Operation: updateOperation,
+		Id:        params.Id,
+		Document:  params.Document,
+		Language:  language,
+	}); err != nil {
+		return Record[S]{}, err
+	}
 
-	return Record[S]{Id: params.Id, Data: params.Document}, nil
+	db.update(params.Id, params.Document, language)
+
+	return Record[S]{Id: params.Id, Data: params.Document, Language: language, Confidence: confidence}, nil
 }
 
 func (db *MemDB[S]) Delete(params *DeleteParams[S]) error {
-	language := params.Language
-	if language == "" {
-		language = db.defaultLanguage
-
-	} else if !tokenizer.IsSupportedLanguage(language) {
-		return &tokenizer.LanguageNotSupportedError{Language: language}
-	}
-
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
-	document, ok := db.documents[params.Id]
-	if !ok {
+	if _, ok := db.documents[params.Id]; !ok {
 		return &DocumentNotFoundError{Id: params.Id}
 	}
 
-	db.index.delete(&indexParams[string, S]{
-		id:              params.Id,
-		document:        document,
-		docsCount:       len(db.documents),
-		language:        language,
-		tokenizerConfig: db.tokenizerConfig,
-	})
+	if err := db.writeLog(&logRecord[S]{
+		Operation: deleteOperation,
+		Id:        params.Id,
+	}); err != nil {
+		return err
+	}
 
-	delete(db.documents, params.Id)
+	db.delete(params.Id)
 
 	return nil
 }
 
 func (db *MemDB[S]) Search(params *SearchParams) (SearchResult[S], error) {
-	allIdScores := make(map[string]float64)
+	result, err := db.search(params)
+	if err != nil || params.Spelling == nil || strings.TrimSpace(params.Query) == "" {
+		return result, err
+	}
+
+	properties := params.Properties
+	if len(params.Properties) == 0 {
+		properties = db.index.searchableProperties
+	}
+
+	correction := db.correct(params.Query, properties, result.Language, params.Spelling)
+	if correction == "" {
+		return result, nil
+	}
+
+	if params.Spelling.AutoCorrect && result.Count == 0 {
+		corrected := *params
+		corrected.Query = correction
+		corrected.Language = result.Language
+		corrected.Spelling = nil
+		if result, err = db.search(&corrected); err != nil {
+			return SearchResult[S]{}, err
+		}
+		result.Corrected = true
+	}
+	result.DidYouMean = correction
+
+	return result, nil
+}
+
+func (db *MemDB[S]) search(params *SearchParams) (SearchResult[S], error) {
 	results := make(SearchHits[S], 0)
 
 	properties := params.Properties
@@ -247,55 +384,192 @@ func (db *MemDB[S]) Search(params *SearchParams) (SearchResult[S], error) {
 		properties = db.index.searchableProperties
 	}
 
-	language := params.Language
-	if params.Language == "" {
-		language = db.defaultLanguage
+	if !isSupportedLanguage(params.Language) {
+		return SearchResult[S]{}, &tokenizer.LanguageNotSupportedError{Language: params.Language}
+	}
+	language, confidence := db.queryLanguage(params.Query, params.Language)
+
+	scoring := params.Scoring
+	if scoring == "" {
+		scoring = db.defaultScoring
+	}
+	scorer, ok := db.scorers[scoring]
+	if !ok {
+		return SearchResult[S]{}, &ScoringNotSupportedError{Scoring: scoring}
+	}
+
+	for prop := range params.Boost {
+		if _, ok := db.index.indexes[prop]; !ok {
+			return SearchResult[S]{}, &WrongSearchPropertyType{Property: prop}
+		}
+	}
 
-	} else if !tokenizer.IsSupportedLanguage(language) {
-		return SearchResult[S]{}, &tokenizer.LanguageNotSupportedError{Language: language}
+	if err := db.index.validateFacets(params.Facets); err != nil {
+		return SearchResult[S]{}, err
+	}
+	if err := db.index.validateSort(params.Sort); err != nil {
+		return SearchResult[S]{}, err
 	}
 
-	tokens, _ := tokenizer.Tokenize(&tokenizer.TokenizeParams{
-		Text:            params.Query,
-		Language:        language,
-		AllowDuplicates: false,
-	}, db.tokenizerConfig)
+	var q query
+	if strings.TrimSpace(params.Query) != "" {
+		var err error
+		if q, err = parseQuery(params.Query); err != nil {
+			return SearchResult[S]{}, err
+		}
+	}
+
+	if params.Vector != nil {
+		if err := db.index.validateVector(params.Vector); err != nil {
+			return SearchResult[S]{}, err
+		}
+	}
+	if err := validateHybrid(&params.Hybrid); err != nil {
+		return SearchResult[S]{}, err
+	}
+	if params.Highlight != nil {
+		if err := db.validateHighlight(params.Highlight); err != nil {
+			return SearchResult[S]{}, err
+		}
+	}
 
 	db.mutex.RLock()
 	defer db.mutex.RUnlock()
 
-	for _, prop := range properties {
-		for _, token := range tokens {
-			idScores, err := db.index.find(&findParams{
-				term:      token,
-				property:  prop,
-				exact:     params.Exact,
-				tolerance: params.Tolerance,
-				relevance: params.Relevance,
-				docsCount: len(db.documents),
-			})
-			if err != nil {
-				return SearchResult[S]{}, err
-			}
-			for id, score := range idScores {
-				allIdScores[id] += score
+	filtered, err := db.index.filter(&params.Filters)
+	if err != nil {
+		return SearchResult[S]{}, err
+	}
+
+	var allIdMatches map[string]match
+	var terms matchedTerms
+	if params.Highlight != nil {
+		terms = make(matchedTerms)
+	}
+
+	if q != nil {
+		allIdMatches, err = db.evaluate(q, &queryContext{
+			properties: properties,
+			language:   language,
+			scorer:     scorer,
+			params:     params,
+			terms:      terms,
+		})
+		if err != nil {
+			return SearchResult[S]{}, err
+		}
+	} else if filtered != nil && params.Vector == nil {
+		// a filter-only search matches every document passing the filters with the same score
+		allIdMatches = make(map[string]match, len(filtered))
+		for id := range filtered {
+			allIdMatches[id] = match{}
+		}
+	}
+
+	if filtered != nil {
+		for id := range allIdMatches {
+			if _, ok := filtered[id]; !ok {
+				delete(allIdMatches, id)
 			}
 		}
 	}
 
-	for id, score := range allIdScores {
+	if params.Vector != nil {
+		vectorMatches := db.evaluateVector(params.Vector, filtered, params.Explain)
+		if q != nil {
+			// a query combined with a vector is a hybrid search fusing the ranks or scores of both
+			allIdMatches = fuse(allIdMatches, vectorMatches, &params.Hybrid, params.Explain)
+		} else {
+			allIdMatches = vectorMatches
+		}
+	}
+
+	for id, m := range allIdMatches {
 		if doc, ok := db.documents[id]; ok {
 			results = append(results, SearchHit[S]{
-				Id:    id,
-				Data:  doc,
-				Score: score,
+				Id:          id,
+				Data:        doc,
+				Score:       m.score,
+				Explanation: m.explanation,
+				Hybrid:      m.hybrid,
 			})
 		}
 	}
 
-	sort.Sort(results)
+	if len(params.Sort) > 0 {
+		db.sort(results, params.Sort)
+	} else {
+		sort.Sort(results)
+	}
+
+	var facets map[string]FacetResult
+	if len(params.Facets) > 0 {
+		ids := make([]string, len(results))
+		for i, hit := range results {
+			ids[i] = hit.Id
+		}
+		facets = db.index.facets(ids, params.Facets)
+	}
 
 	start, stop := lib.Paginate(params.Offset, params.Limit, len(results))
+	hits := results[start:stop]
+
+	if params.Highlight != nil {
+		for i := range hits {
+			hits[i].Highlights = db.highlight(hits[i].Data, terms, properties, db.languages[hits[i].Id], params.Highlight)
+		}
+	}
+
+	return SearchResult[S]{
+		Hits:       hits,
+		Count:      len(results),
+		Facets:     facets,
+		Language:   language,
+		Confidence: confidence,
+	}, nil
+}
+
+func (db *MemDB[S]) insert(id string, document S, language tokenizer.Language) {
+	db.documents[id] = document
+	db.languages[id] = language
+
+	db.index.insert(&indexParams[string, S]{
+		id:        id,
+		document:  document,
+		docsCount: len(db.documents),
+		analyzers: db.propertyAnalyzers(language),
+	})
+}
+
+func (db *MemDB[S]) update(id string, document S, language tokenizer.Language) {
+	// remove the old postings first so that tokens shared by both versions stay indexed,
+	// analyzing the old version in the language it was indexed with
+	db.index.delete(&indexParams[string, S]{
+		id:        id,
+		document:  db.documents[id],
+		docsCount: len(db.documents),
+		analyzers: db.propertyAnalyzers(db.languages[id]),
+	})
+
+	db.documents[id] = document
+	db.languages[id] = language
+
+	db.index.insert(&indexParams[string, S]{
+		id:        id,
+		document:  document,
+		docsCount: len(db.documents),
+		analyzers: db.propertyAnalyzers(language),
+	})
+}
+
+func (db *MemDB[S]) delete(id string) {
+	db.index.delete(&indexParams[string, S]{
+		id:        id,
+		document:  db.documents[id],
+		docsCount: len(db.documents),
+		analyzers: db.propertyAnalyzers(db.languages[id]),
+	})
 
-	return SearchResult[S]{Hits: results[start:stop], Count: len(results)}, nil
+	delete(db.documents, id)
+	delete(db.languages, id)
 }
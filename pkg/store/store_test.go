@@ -1,11 +1,20 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 	"log"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/micpst/minisearch/pkg/hnsw"
+	"github.com/micpst/minisearch/pkg/lib"
 	"github.com/micpst/minisearch/pkg/tokenizer"
+	"github.com/micpst/minisearch/pkg/wal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,6 +41,49 @@ type Document struct {
 	Author   User `index:"author"`
 }
 
+type BoostedDocument struct {
+	Title    string `index:"title,boost=2"`
+	Abstract string `index:"abstract"`
+}
+
+type Article struct {
+	Title      string    `index:"title"`
+	Subtitle   *string   `index:"subtitle"`
+	Paragraphs []string  `index:"paragraphs"`
+	Tags       []string  `index:"tags,keyword"`
+	Ratings    []int     `index:"ratings"`
+	Authors    []User    `index:"authors"`
+	Editor     *User     `index:"editor"`
+	Published  time.Time `index:"published"`
+}
+
+type Embedded struct {
+	Title     string    `index:"title"`
+	Category  string    `index:"category,keyword"`
+	Embedding []float32 `index:"embedding,vector,dims=3"`
+	Location  []float32 `index:"location,vector,dims=2,metric=l2"`
+}
+
+type Product struct {
+	Name      string  `index:"name"`
+	Category  string  `index:"category,keyword"`
+	Available bool    `index:"available"`
+	Price     float64 `index:"price"`
+	Stock     uint    `index:"stock"`
+	Year      int     `index:"year"`
+}
+
+type Listing struct {
+	Title       string `index:"title"`
+	Sku         string `index:"sku,analyzer=keyword"`
+	Description string `index:"description,analyzer=en,stem=false,stopwords=false"`
+}
+
+type Review struct {
+	Text     string `index:"text"`
+	Language string `index:"lang,language"`
+}
+
 var testData = []User{
 	{"Tom Haris", "tom@email.com", "2023-02-10T15:04:05Z07:00"},
 	{"Jane", "myne123@email.com", "2023-02-10T15:04:06Z07:00"},
@@ -84,7 +136,7 @@ func TestInsert(t *testing.T) {
 	}
 	for _, c := range cases {
 		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
-			db := New[User](&Config{
+			db, _ := New[User](&Config{
 				DefaultLanguage: tokenizer.ENGLISH,
 				TokenizerConfig: &tokenizer.Config{},
 			})
@@ -127,7 +179,7 @@ func TestInsertBatch(t *testing.T) {
 	}
 	for _, c := range cases {
 		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
-			db := New[User](&Config{
+			db, _ := New[User](&Config{
 				DefaultLanguage: tokenizer.ENGLISH,
 				TokenizerConfig: &tokenizer.Config{},
 			})
@@ -146,7 +198,7 @@ func TestInsertBatch(t *testing.T) {
 }
 
 func TestSearch(t *testing.T) {
-	db := New[User](&Config{
+	db, _ := New[User](&Config{
 		DefaultLanguage: tokenizer.ENGLISH,
 		TokenizerConfig: &tokenizer.Config{},
 	})
@@ -190,7 +242,7 @@ func TestSearch(t *testing.T) {
 				Hits: []SearchHit[User]{
 					{
 						Data:  testData[6],
-						Score: 5.083472618048522,
+						Score: 3.984860329380412,
 					},
 					{
 						Data:  testData[0],
@@ -218,35 +270,2197 @@ func TestSearch(t *testing.T) {
 	}
 }
 
-func TestFlattenSchema(t *testing.T) {
-	cases := []TestCase[any, map[string]any]{
+func TestSearchBoolean(t *testing.T) {
+	db, _ := New[User](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	db.InsertBatch(&InsertBatchParams[User]{
+		Documents: testData,
+		BatchSize: 3,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	cases := []TestCase[string, []User]{
 		{
-			given: User{
-				Name:   "micpst",
-				Email:  "micpst@email.com",
-				Joined: "2023-02-10T15:04:05Z07:00",
+			given:    "tom AND brown",
+			expected: []User{testData[4]},
+		},
+		{
+			given:    "tom -brown",
+			expected: []User{testData[0]},
+		},
+		{
+			given:    "brown NOT bob",
+			expected: []User{testData[4]},
+		},
+		{
+			given:    "(tom OR charlie) AND NOT davis",
+			expected: []User{testData[0], testData[4], testData[5]},
+		},
+		{
+			given:    "+anderson charlie",
+			expected: []User{testData[5], testData[9]},
+		},
+		{
+			given:    "tom NOT -brown",
+			expected: []User{testData[0]},
+		},
+		{
+			given:    "++anderson charlie",
+			expected: []User{testData[5], testData[9]},
+		},
+		{
+			given:    "-tom -charlie -anderson",
+			expected: []User{testData[1], testData[2], testData[6], testData[7], testData[8]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given, func(t *testing.T) {
+			actual, err := db.Search(&SearchParams{
+				Query:      c.given,
+				Properties: []string{"name"},
+				Limit:      10,
+			})
+			assert.NoError(t, err)
+
+			docs := make([]User, 0, len(actual.Hits))
+			for _, hit := range actual.Hits {
+				docs = append(docs, hit.Data)
+			}
+			assert.ElementsMatch(t, c.expected, docs)
+		})
+	}
+}
+
+func TestSearchPhrase(t *testing.T) {
+	documents := []Document{
+		{Title: "Brain", Abstract: "The silicon brain is a brain made of silicon"},
+		{Title: "Valley", Abstract: "Silicon valley builds a brain computer interface"},
+		{Title: "Reversed", Abstract: "A brain of silicon"},
+	}
+
+	db, _ := New[Document](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	db.InsertBatch(&InsertBatchParams[Document]{
+		Documents: documents,
+		BatchSize: 3,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	cases := []TestCase[string, []Document]{
+		{
+			given:    `"silicon brain"`,
+			expected: []Document{documents[0]},
+		},
+		{
+			given:    `"silicon brain"~3`,
+			expected: []Document{documents[0], documents[1]},
+		},
+		{
+			given:    `"silicon brain"~2`,
+			expected: []Document{documents[0]},
+		},
+		{
+			given:    `brain -"silicon brain"`,
+			expected: []Document{documents[1], documents[2]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given, func(t *testing.T) {
+			actual, err := db.Search(&SearchParams{
+				Query:      c.given,
+				Properties: []string{"abstract"},
+				Relevance:  BM25Params{K: 1.2, B: 0.75, D: 0.5},
+				Limit:      10,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, len(c.expected), actual.Count)
+
+			docs := make([]Document, 0, len(actual.Hits))
+			for _, hit := range actual.Hits {
+				docs = append(docs, hit.Data)
+			}
+			assert.ElementsMatch(t, c.expected, docs)
+		})
+	}
+
+	// adjacent matches outrank scattered ones
+	actual, _ := db.Search(&SearchParams{
+		Query:      `"silicon brain"~3`,
+		Properties: []string{"abstract"},
+		Relevance:  BM25Params{K: 1.2, B: 0.75, D: 0.5},
+		Limit:      10,
+	})
+	assert.Equal(t, documents[0], actual.Hits[0].Data)
+	assert.Greater(t, actual.Hits[0].Score, actual.Hits[1].Score)
+}
+
+func TestSearchFields(t *testing.T) {
+	documents := []Document{
+		{Title: "Silicon brain", Abstract: "Neural network hardware", Author: User{Name: "micpst"}},
+		{Title: "Neural network", Abstract: "The brain of a computer", Author: User{Name: "Tom Brown"}},
+		{Title: "Networks", Abstract: "A neural network in a brain", Author: User{Name: "Mick"}},
+	}
+
+	db, _ := New[Document](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	db.InsertBatch(&InsertBatchParams[Document]{
+		Documents: documents,
+		BatchSize: 3,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	cases := []TestCase[string, []Document]{
+		{
+			given:    "title:brain",
+			expected: []Document{documents[0]},
+		},
+		{
+			given:    `title:brain abstract:"neural network"`,
+			expected: []Document{documents[0], documents[2]},
+		},
+		{
+			given:    `author.name:mic AND brain`,
+			expected: []Document{documents[0], documents[2]},
+		},
+		{
+			given:    `abstract:(brain -neural)`,
+			expected: []Document{documents[1]},
+		},
+		{
+			given:    "brain 10:30",
+			expected: []Document{documents[0], documents[1], documents[2]},
+		},
+		{
+			given:    "+network 4:3",
+			expected: []Document{documents[0], documents[1], documents[2]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given, func(t *testing.T) {
+			actual, err := db.Search(&SearchParams{
+				Query: c.given,
+				Limit: 10,
+			})
+			assert.NoError(t, err)
+
+			docs := make([]Document, 0, len(actual.Hits))
+			for _, hit := range actual.Hits {
+				docs = append(docs, hit.Data)
+			}
+			assert.ElementsMatch(t, c.expected, docs)
+		})
+	}
+
+	_, err := db.Search(&SearchParams{
+		Query: "url:micpst",
+		Limit: 10,
+	})
+	assert.Equal(t, &WrongSearchPropertyType{Property: "url"}, err)
+}
+
+func TestSearchBoost(t *testing.T) {
+	documents := []BoostedDocument{
+		{Title: "Brain", Abstract: "Silicon"},
+		{Title: "Silicon", Abstract: "Brain"},
+	}
+
+	db, _ := New[BoostedDocument](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	db.InsertBatch(&InsertBatchParams[BoostedDocument]{
+		Documents: documents,
+		BatchSize: 2,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	cases := []TestCase[map[string]float64, []BoostedDocument]{
+		{
+			given:    nil,
+			expected: []BoostedDocument{documents[0], documents[1]},
+		},
+		{
+			given:    map[string]float64{"title": 0.5},
+			expected: []BoostedDocument{documents[1], documents[0]},
+		},
+		{
+			given:    map[string]float64{"abstract": 3},
+			expected: []BoostedDocument{documents[1], documents[0]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			actual, err := db.Search(&SearchParams{
+				Query:     "brain",
+				Boost:     c.given,
+				Relevance: BM25Params{K: 1.2, B: 0.75, D: 0.5},
+				Limit:     10,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, len(c.expected), len(actual.Hits))
+
+			for i, doc := range c.expected {
+				assert.Equal(t, doc, actual.Hits[i].Data)
+			}
+		})
+	}
+
+	_, err := db.Search(&SearchParams{
+		Query: "brain",
+		Boost: map[string]float64{"url": 2},
+	})
+	assert.Equal(t, &WrongSearchPropertyType{Property: "url"}, err)
+}
+
+func TestSearchPenalties(t *testing.T) {
+	documents := []User{
+		{Name: "Carla Hart", Email: "hart@email.com"},
+		{Name: "Carl Hart", Email: "hart@email.com"},
+		{Name: "Karl Hart", Email: "hart@email.com"},
+	}
+
+	db, _ := New[User](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	db.InsertBatch(&InsertBatchParams[User]{
+		Documents: documents,
+		BatchSize: 3,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	cases := []TestCase[SearchParams, []User]{
+		{
+			given: SearchParams{
+				Query:      "carl",
+				Properties: []string{"name"},
+				Penalties:  PenaltyParams{Prefix: 0.3},
 			},
-			expected: map[string]any{
-				"name":  "micpst",
-				"email": "micpst@email.com",
+			expected: []User{documents[1], documents[0]},
+		},
+		{
+			given: SearchParams{
+				Query:      "carl",
+				Properties: []string{"name"},
+				Tolerance:  1,
+				Penalties:  PenaltyParams{Edit: 0.5},
+			},
+			expected: []User{documents[1], documents[0]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			c.given.Relevance = BM25Params{K: 1.2, B: 0.75, D: 0.5}
+			c.given.Limit = 10
+
+			actual, err := db.Search(&c.given)
+			assert.NoError(t, err)
+			assert.Equal(t, len(c.expected), len(actual.Hits))
+
+			for i, doc := range c.expected {
+				assert.Equal(t, doc, actual.Hits[i].Data)
+			}
+		})
+	}
+}
+
+func TestIndexFind(t *testing.T) {
+	db, _ := New[User](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	db.InsertBatch(&InsertBatchParams[User]{
+		Documents: testData,
+		BatchSize: 5,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	results, err := db.index.find(&findParams{term: "char", property: "name"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "charlie", results[0].term)
+
+	for _, stats := range results[0].idStats {
+		assert.Equal(t, 2, stats.DocumentFrequency)
+	}
+}
+
+func TestSearchExplain(t *testing.T) {
+	db, _ := New[User](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	db.InsertBatch(&InsertBatchParams[User]{
+		Documents: testData,
+		BatchSize: 5,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	params := &SearchParams{
+		Query:      "julia tom",
+		Properties: []string{"name", "email"},
+		Relevance:  BM25Params{K: 1.2, B: 0.75, D: 0.5},
+		Limit:      10,
+	}
+
+	result, err := db.Search(params)
+	assert.NoError(t, err)
+	for _, hit := range result.Hits {
+		assert.Nil(t, hit.Explanation)
+	}
+
+	params.Explain = true
+	result, err = db.Search(params)
+	assert.NoError(t, err)
+
+	hit := result.Hits[0]
+	assert.Equal(t, testData[6], hit.Data)
+	assert.Equal(t, hit.Score, hit.Explanation.Score)
+	assert.Equal(t, "sum of", hit.Explanation.Description)
+	assert.Len(t, hit.Explanation.Details, 1)
+
+	token := hit.Explanation.Details[0]
+	assert.Equal(t, "token", token.Description)
+	assert.Equal(t, "julia", token.Token)
+	assert.Len(t, token.Details, 2)
+
+	terms := make(map[string]*Explanation)
+	for _, term := range token.Details {
+		assert.Equal(t, "term", term.Description)
+		assert.Equal(t, &params.Relevance, term.Relevance)
+		terms[term.Term] = term
+	}
+	assert.InDelta(t, hit.Score, terms["julia"].Score+terms["juliah"].Score, 1e-9)
+
+	assert.Equal(t, []FieldStats{{
+		Property:          "name",
+		TermFrequency:     0.5,
+		DocumentFrequency: 1,
+		FieldLength:       2,
+		AvgFieldLength:    1.8,
+		Boost:             1,
+	}}, terms["julia"].Fields)
+	assert.Equal(t, "email", terms["juliah"].Fields[0].Property)
+}
+
+func TestSearchHighlight(t *testing.T) {
+	db, _ := New[Document](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{EnableStemming: true},
+	})
+	_, _ = db.Insert(&InsertParams[Document]{Document: Document{
+		Title: "The Silicon Brain",
+		Abstract: "The human brain is often described as the most complex object in the known universe. " +
+			"Brains of animals differ, yet a silicon brain is built from transistors rather than neurons.",
+	}})
+
+	cases := []TestCase[SearchParams, map[string][]string]{
+		{
+			given: SearchParams{Query: "brains", Highlight: &HighlightParams{}},
+			expected: map[string][]string{
+				"title": {"The Silicon <em>Brain</em>"},
+				"abstract": {
+					"The human <em>brain</em> is often described as the most complex object in the known universe. <em>Brains</em> of",
+					"animals differ, yet a silicon <em>brain</em> is built from transistors rather than neurons.",
+				},
 			},
 		},
 		{
-			given: Document{
-				Title:    "The Silicon Brain",
-				Abstract: "The human brain is often described as complex and while this is certainly true in many ways, its computational substrate is quite easy to understand.",
-				Url:      "https://micpst.com/posts/silicon-brain",
-				Author: User{
-					Name:   "micpst",
-					Email:  "micpst@email.com",
-					Joined: "2023-02-10T15:04:05Z07:00",
+			given: SearchParams{Query: "brains", Highlight: &HighlightParams{FragmentSize: 40, Fragments: 2}},
+			expected: map[string][]string{
+				"title": {"The Silicon <em>Brain</em>"},
+				"abstract": {
+					"The human <em>brain</em> is often described as",
+					"known universe. <em>Brains</em> of animals",
 				},
 			},
-			expected: map[string]any{
-				"title":        "The Silicon Brain",
-				"abstract":     "The human brain is often described as complex and while this is certainly true in many ways, its computational substrate is quite easy to understand.",
-				"author.name":  "micpst",
-				"author.email": "micpst@email.com",
+		},
+		{
+			given: SearchParams{Query: "sili", Highlight: &HighlightParams{Properties: []string{"title"}, PreTag: "[", PostTag: "]"}},
+			expected: map[string][]string{
+				"title": {"The [Silicon] Brain"},
+			},
+		},
+		{
+			given: SearchParams{Query: "title:brain", Highlight: &HighlightParams{}},
+			expected: map[string][]string{
+				"title": {"The Silicon <em>Brain</em>"},
+			},
+		},
+		{
+			given: SearchParams{Query: `"silicon brain"`, Highlight: &HighlightParams{FragmentSize: 30}},
+			expected: map[string][]string{
+				"title": {"The <em>Silicon</em> <em>Brain</em>"},
+				"abstract": {
+					"The human <em>brain</em> is often",
+					"universe. <em>Brains</em> of animals",
+					"yet a <em>silicon</em> <em>brain</em> is",
+				},
+			},
+		},
+		{
+			given: SearchParams{Query: "human -carbon", Highlight: &HighlightParams{}},
+			expected: map[string][]string{
+				"abstract": {"The <em>human</em> brain is often described as the most complex object in the known universe. Brains of"},
+			},
+		},
+		{
+			given:    SearchParams{Query: "brain"},
+			expected: nil,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given.Query, func(t *testing.T) {
+			c.given.Limit = 10
+
+			actual, err := db.Search(&c.given)
+			assert.NoError(t, err)
+			assert.Len(t, actual.Hits, 1)
+			assert.Equal(t, c.expected, actual.Hits[0].Highlights)
+		})
+	}
+
+	// a hit is highlighted in the language of the document rather than of the query
+	french, _ := db.Insert(&InsertParams[Document]{Document: Document{Title: "Les chevaux sauvages"}, Language: tokenizer.FRENCH})
+	result, err := db.Search(&SearchParams{Query: "cheval", Limit: 10, Highlight: &HighlightParams{}})
+	assert.NoError(t, err)
+	assert.Len(t, result.Hits, 1)
+	assert.Equal(t, french.Id, result.Hits[0].Id)
+	assert.Equal(t, map[string][]string{"title": {"Les <em>chevaux</em> sauvages"}}, result.Hits[0].Highlights)
+
+	_, err = db.Search(&SearchParams{Query: "brain", Highlight: &HighlightParams{Properties: []string{"url"}}})
+	assert.Equal(t, &WrongSearchPropertyType{Property: "url"}, err)
+}
+
+func TestNumericIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	online := newNumericIndex[int]()
+	values := make(map[int]float64)
+	for id := 0; id < 5*numericChunkSize; id++ {
+		// few distinct values, so that equal values span several chunks
+		values[id] = float64(r.Intn(50))
+		online.insert(id, values[id])
+	}
+	for id := 0; id < len(values); id += 3 {
+		online.delete(id)
+		delete(values, id)
+	}
+
+	entries := make([]numericEntry[int], 0, len(values))
+	for id, value := range values {
+		entries = append(entries, numericEntry[int]{value: value, id: id})
+	}
+	loaded := newNumericIndex[int]()
+	loaded.load(entries)
+
+	lo, hi := 10.0, 20.0
+	expected := make(map[int]struct{})
+	for id, value := range values {
+		if value > lo && value <= hi {
+			expected[id] = struct{}{}
+		}
+	}
+	for _, n := range []*numericIndex[int]{online, loaded} {
+		assert.Equal(t, expected, n.find(&RangeFilter{Gt: &lo, Lte: &hi}))
+		assert.Len(t, n.entries(), len(values))
+		for _, chunk := range n.chunks {
+			assert.Less(t, len(chunk), 2*numericChunkSize)
+		}
+	}
+}
+
+func TestSearchFilters(t *testing.T) {
+	products := []Product{
+		{Name: "Red chair", Category: "furniture", Available: true, Price: 49.99, Stock: 10, Year: 2019},
+		{Name: "Blue chair", Category: "furniture", Price: 79.5, Stock: 0, Year: 2021},
+		{Name: "Red table", Category: "furniture", Available: true, Price: 149, Stock: 3, Year: 2021},
+		{Name: "Green lamp", Category: "lighting", Available: true, Price: 19.99, Stock: 25, Year: 2023},
+	}
+
+	db, _ := New[Product](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	ids := make([]string, len(products))
+	for i, product := range products {
+		record, _ := db.Insert(&InsertParams[Product]{Document: product})
+		ids[i] = record.Id
+	}
+
+	number := func(value float64) *float64 { return &value }
+
+	cases := []TestCase[SearchParams, []Product]{
+		{
+			given: SearchParams{
+				Query:   "chair",
+				Filters: Filters{Range: map[string]RangeFilter{"price": {Lt: number(50)}}},
+			},
+			expected: []Product{products[0]},
+		},
+		{
+			given: SearchParams{
+				Query:   "red",
+				Filters: Filters{Range: map[string]RangeFilter{"year": {Eq: number(2021)}}},
+			},
+			expected: []Product{products[2]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{Range: map[string]RangeFilter{"price": {Between: &[2]float64{19.99, 79.5}}}},
+			},
+			expected: []Product{products[0], products[1], products[3]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{Range: map[string]RangeFilter{
+					"stock": {Gt: number(0)},
+					"year":  {Gte: number(2021), Lte: number(2023)},
+				}},
+			},
+			expected: []Product{products[2], products[3]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{Range: map[string]RangeFilter{"price": {Gt: number(49.99), Lt: number(149)}}},
+			},
+			expected: []Product{products[1]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{Term: map[string]any{"category": "lighting"}},
+			},
+			expected: []Product{products[3]},
+		},
+		{
+			given: SearchParams{
+				Query: "red",
+				Filters: Filters{
+					Term:  map[string]any{"available": true},
+					Terms: map[string][]any{"category": {"furniture", "lighting"}},
+				},
+			},
+			expected: []Product{products[0], products[2]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{
+					Range: map[string]RangeFilter{"price": {Gte: number(50)}},
+					Terms: map[string][]any{"category": {"furniture"}},
+				},
+			},
+			expected: []Product{products[1], products[2]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{Term: map[string]any{"category": "Furniture"}},
+			},
+			expected: []Product{},
+		},
+		{
+			given:    SearchParams{},
+			expected: []Product{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			c.given.Limit = 10
+
+			actual, err := db.Search(&c.given)
+			assert.NoError(t, err)
+
+			docs := make([]Product, 0, len(actual.Hits))
+			for _, hit := range actual.Hits {
+				docs = append(docs, hit.Data)
+			}
+			assert.ElementsMatch(t, c.expected, docs)
+		})
+	}
+
+	_, err := db.Search(&SearchParams{
+		Filters: Filters{Range: map[string]RangeFilter{"name": {Eq: number(1)}}},
+	})
+	assert.Equal(t, &WrongFilterPropertyType{Property: "name"}, err)
+
+	_, err = db.Search(&SearchParams{
+		Filters: Filters{Term: map[string]any{"price": 49.99}},
+	})
+	assert.Equal(t, &WrongFilterPropertyType{Property: "price"}, err)
+
+	_, err = db.Search(&SearchParams{
+		Query:      "furniture",
+		Properties: []string{"category"},
+	})
+	assert.Equal(t, &WrongSearchPropertyType{Property: "category"}, err)
+
+	// filters do not affect the scores of the matching documents
+	unfiltered, _ := db.Search(&SearchParams{Query: "red", Limit: 10})
+	filtered, _ := db.Search(&SearchParams{
+		Query:   "red",
+		Filters: Filters{Term: map[string]any{"category": "furniture"}},
+		Limit:   10,
+	})
+	assert.ElementsMatch(t, unfiltered.Hits, filtered.Hits)
+
+	_, _ = db.Update(&UpdateParams[Product]{Id: ids[0], Document: Product{Name: "Red chair", Category: "outdoor", Price: 59.99}})
+	_ = db.Delete(&DeleteParams[Product]{Id: ids[3]})
+
+	actual, _ := db.Search(&SearchParams{
+		Filters: Filters{Range: map[string]RangeFilter{"price": {Lte: number(60)}}},
+		Limit:   10,
+	})
+	assert.Equal(t, 1, actual.Count)
+	assert.Equal(t, 59.99, actual.Hits[0].Data.Price)
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, db.Save(&buf))
+	loaded, err := Load[Product](&buf, &Config{DefaultLanguage: tokenizer.ENGLISH, TokenizerConfig: &tokenizer.Config{}})
+	assert.NoError(t, err)
+	actual, _ = loaded.Search(&SearchParams{
+		Filters: Filters{Terms: map[string][]any{"category": {"outdoor", "lighting"}}},
+		Limit:   10,
+	})
+	assert.Equal(t, 1, actual.Count)
+	assert.Equal(t, "outdoor", actual.Hits[0].Data.Category)
+
+	for prop, keywords := range db.index.keywords {
+		assert.Equal(t, keywords.values, loaded.index.keywords[prop].values)
+		assert.Equal(t, keywords.ids, loaded.index.keywords[prop].ids)
+	}
+	for prop, numbers := range db.index.numbers {
+		assert.Equal(t, numbers.values, loaded.index.numbers[prop].values)
+		assert.ElementsMatch(t, numbers.entries(), loaded.index.numbers[prop].entries())
+	}
+}
+
+func TestSearchFacets(t *testing.T) {
+	products := []Product{
+		{Name: "Red chair", Category: "furniture", Available: true, Price: 49.99, Year: 2019},
+		{Name: "Blue chair", Category: "furniture", Price: 79.5, Year: 2021},
+		{Name: "Red table", Category: "furniture", Available: true, Price: 149, Year: 2021},
+		{Name: "Red lamp", Category: "lighting", Available: true, Price: 19.99, Year: 2023},
+		{Name: "Green lamp", Category: "lighting", Price: 24.99, Year: 2023},
+		{Name: "Red rug", Category: "textiles", Price: 99, Year: 2023},
+	}
+
+	db, _ := New[Product](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	for _, product := range products {
+		_, _ = db.Insert(&InsertParams[Product]{Document: product})
+	}
+
+	number := func(value float64) *float64 { return &value }
+
+	actual, err := db.Search(&SearchParams{
+		Query: "red",
+		Facets: map[string]FacetParams{
+			"category":  {Limit: 2},
+			"available": {},
+			"year":      {},
+			"price": {Ranges: []FacetRange{
+				{To: number(50)},
+				{From: number(50), To: number(100)},
+				{From: number(100)},
+			}},
+		},
+		Limit: 1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, actual.Count)
+	assert.Len(t, actual.Hits, 1)
+
+	assert.Equal(t, map[string]FacetResult{
+		"category": {
+			Count:  3,
+			Values: []FacetValue{{Value: "furniture", Count: 2}, {Value: "lighting", Count: 1}},
+		},
+		"available": {
+			Count:  2,
+			Values: []FacetValue{{Value: true, Count: 3}, {Value: false, Count: 1}},
+		},
+		"year": {
+			Count:  3,
+			Values: []FacetValue{{Value: float64(2023), Count: 2}, {Value: float64(2019), Count: 1}, {Value: float64(2021), Count: 1}},
+		},
+		"price": {
+			Ranges: []FacetRangeCount{
+				{To: number(50), Count: 2},
+				{From: number(50), To: number(100), Count: 1},
+				{From: number(100), Count: 1},
+			},
+		},
+	}, actual.Facets)
+
+	actual, err = db.Search(&SearchParams{
+		Filters: Filters{Term: map[string]any{"category": "lighting"}},
+		Facets:  map[string]FacetParams{"available": {}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []FacetValue{{Value: false, Count: 1}, {Value: true, Count: 1}}, actual.Facets["available"].Values)
+
+	_, err = db.Search(&SearchParams{
+		Query:  "red",
+		Facets: map[string]FacetParams{"name": {}},
+	})
+	assert.Equal(t, &WrongFacetPropertyType{Property: "name"}, err)
+
+	_, err = db.Search(&SearchParams{
+		Query:  "red",
+		Facets: map[string]FacetParams{"category": {Ranges: []FacetRange{{To: number(1)}}}},
+	})
+	assert.Equal(t, &WrongFacetPropertyType{Property: "category"}, err)
+}
+
+func TestSearchSort(t *testing.T) {
+	products := []Product{
+		{Name: "Red chair", Category: "furniture", Price: 49.99, Year: 2019},
+		{Name: "Blue chair", Category: "furniture", Price: 79.5, Year: 2021},
+		{Name: "Red table", Category: "furniture", Price: 149, Year: 2021},
+		{Name: "Red lamp", Category: "lighting", Price: 19.99, Year: 2023},
+		{Name: "Red rug", Category: "textiles", Price: 99, Year: 2023},
+	}
+
+	db, _ := New[Product](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	for _, product := range products {
+		_, _ = db.Insert(&InsertParams[Product]{Document: product})
+	}
+
+	cases := []TestCase[[]SortParams, []Product]{
+		{
+			given:    []SortParams{{Field: "price"}},
+			expected: []Product{products[3], products[0], products[4], products[2]},
+		},
+		{
+			given:    []SortParams{{Field: "year", Order: DESC}, {Field: "price", Order: ASC}},
+			expected: []Product{products[3], products[4], products[2], products[0]},
+		},
+		{
+			given:    []SortParams{{Field: "category", Order: DESC}, {Field: "year"}},
+			expected: []Product{products[4], products[3], products[0], products[2]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			actual, err := db.Search(&SearchParams{
+				Query: "red",
+				Sort:  c.given,
+				Limit: 10,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, len(c.expected), len(actual.Hits))
+
+			for i, doc := range c.expected {
+				assert.Equal(t, doc, actual.Hits[i].Data)
+			}
+		})
+	}
+
+	// ties are broken by id, so the pages never overlap
+	ids := make([]string, 0, len(products))
+	for offset := 0; offset < len(products); offset += 2 {
+		actual, _ := db.Search(&SearchParams{
+			Filters: Filters{Range: map[string]RangeFilter{"price": {Gte: new(float64)}}},
+			Offset:  offset,
+			Limit:   2,
+		})
+		for _, hit := range actual.Hits {
+			ids = append(ids, hit.Id)
+		}
+	}
+	assert.Len(t, ids, len(products))
+	assert.IsIncreasing(t, ids)
+
+	actual, _ := db.Search(&SearchParams{
+		Query: "red",
+		Sort:  []SortParams{{Field: ScoreField}, {Field: IdField, Order: DESC}},
+		Limit: 10,
+	})
+	for i := 1; i < len(actual.Hits); i++ {
+		prev, curr := actual.Hits[i-1], actual.Hits[i]
+		assert.True(t, prev.Score > curr.Score || prev.Score == curr.Score && prev.Id > curr.Id)
+	}
+
+	_, err := db.Search(&SearchParams{Query: "red", Sort: []SortParams{{Field: "name"}}})
+	assert.Equal(t, &WrongSortPropertyType{Property: "name"}, err)
+
+	_, err = db.Search(&SearchParams{Query: "red", Sort: []SortParams{{Field: "price", Order: "up"}}})
+	assert.Equal(t, &SortOrderNotSupportedError{Order: "up"}, err)
+}
+
+func TestSearchMultiValued(t *testing.T) {
+	subtitle := "A gentle introduction"
+	articles := []Article{
+		{
+			Title:      "Silicon brain",
+			Subtitle:   &subtitle,
+			Paragraphs: []string{"Neurons fire", "Chips compute"},
+			Tags:       []string{"science", "hardware"},
+			Ratings:    []int{3, 5},
+			Authors:    []User{{Name: "Tom Haris"}, {Name: "Julia Hernandez"}},
+			Editor:     &User{Name: "Bob Brown"},
+			Published:  time.Date(2023, 2, 10, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			Title:      "Quantum chips",
+			Paragraphs: []string{"Chips fire photons"},
+			Tags:       []string{"science"},
+			Ratings:    []int{4},
+			Authors:    []User{{Name: "Eve Anderson"}},
+			Published:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	db, err := New[Article](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	assert.NoError(t, err)
+
+	ids := make([]string, len(articles))
+	for i, article := range articles {
+		record, _ := db.Insert(&InsertParams[Article]{Document: article})
+		ids[i] = record.Id
+	}
+
+	// the values of a multi-valued property share a single field length
+	assert.Equal(t, 4, db.index.fieldLengths["paragraphs"][ids[0]])
+	assert.Equal(t, 4, db.index.fieldLengths["authors.name"][ids[0]])
+	assert.Equal(t, 0, db.index.fieldLengths["editor.name"][ids[1]])
+	assert.Equal(t, 0, db.index.fieldLengths["subtitle"][ids[1]])
+
+	number := func(value float64) *float64 { return &value }
+
+	cases := []TestCase[SearchParams, []Article]{
+		{
+			given:    SearchParams{Query: `paragraphs:"chips fire"`},
+			expected: []Article{articles[1]},
+		},
+		{
+			given:    SearchParams{Query: `paragraphs:"fire chips"~3`},
+			expected: []Article{},
+		},
+		{
+			given:    SearchParams{Query: `paragraphs:"fire chips"~1000`},
+			expected: []Article{},
+		},
+		{
+			given:    SearchParams{Query: `paragraphs:"neurons compute"~1000`},
+			expected: []Article{},
+		},
+		{
+			given:    SearchParams{Query: "authors.name:julia"},
+			expected: []Article{articles[0]},
+		},
+		{
+			given:    SearchParams{Query: "editor.name:bob subtitle:gentle"},
+			expected: []Article{articles[0]},
+		},
+		{
+			given:    SearchParams{Filters: Filters{Term: map[string]any{"tags": "hardware"}}},
+			expected: []Article{articles[0]},
+		},
+		{
+			given:    SearchParams{Filters: Filters{Range: map[string]RangeFilter{"ratings": {Gte: number(5)}}}},
+			expected: []Article{articles[0]},
+		},
+		{
+			given: SearchParams{Filters: Filters{Range: map[string]RangeFilter{
+				"published": {Gte: number(float64(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()))},
+			}}},
+			expected: []Article{articles[1]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{Term: map[string]any{"tags": "science"}},
+				Sort:    []SortParams{{Field: "ratings", Order: DESC}},
+			},
+			expected: []Article{articles[0], articles[1]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{Term: map[string]any{"tags": "science"}},
+				Sort:    []SortParams{{Field: "ratings", Order: ASC}},
+			},
+			expected: []Article{articles[0], articles[1]},
+		},
+		{
+			given: SearchParams{
+				Filters: Filters{Term: map[string]any{"tags": "science"}},
+				Sort:    []SortParams{{Field: "published", Order: DESC}},
+			},
+			expected: []Article{articles[1], articles[0]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			c.given.Limit = 10
+
+			actual, err := db.Search(&c.given)
+			assert.NoError(t, err)
+			assert.Equal(t, len(c.expected), len(actual.Hits))
+
+			for i, doc := range c.expected {
+				assert.Equal(t, doc, actual.Hits[i].Data)
+			}
+		})
+	}
+
+	actual, _ := db.Search(&SearchParams{
+		Query:  "chips",
+		Facets: map[string]FacetParams{"tags": {}},
+	})
+	assert.Equal(t, []FacetValue{{Value: "science", Count: 2}, {Value: "hardware", Count: 1}}, actual.Facets["tags"].Values)
+
+	assert.NoError(t, db.Delete(&DeleteParams[Article]{Id: ids[0]}))
+	assert.Equal(t, 1, db.index.tokenOccurrences["paragraphs"]["chips"])
+	assert.NotContains(t, db.index.tokenOccurrences["authors.name"], "julia")
+	assert.NotContains(t, db.index.keywords["tags"].ids, "hardware")
+	assert.Len(t, db.index.numbers["ratings"].entries(), 1)
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, db.Save(&buf))
+	loaded, err := Load[Article](&buf, &Config{DefaultLanguage: tokenizer.ENGLISH, TokenizerConfig: &tokenizer.Config{}})
+	assert.NoError(t, err)
+	assert.Equal(t, db.documents, loaded.documents)
+	assert.Equal(t, db.index.numbers["published"].values, loaded.index.numbers["published"].values)
+}
+
+func TestSearchVector(t *testing.T) {
+	documents := []Embedded{
+		{Title: "North", Category: "a", Embedding: []float32{1, 0, 0}, Location: []float32{0, 10}},
+		{Title: "North east", Category: "b", Embedding: []float32{1, 1, 0}, Location: []float32{5, 5}},
+		{Title: "East", Category: "a", Embedding: []float32{0, 1, 0}, Location: []float32{10, 0}},
+		{Title: "Up", Category: "b", Embedding: []float32{0, 0, 1}},
+	}
+
+	var db *MemDB[Embedded]
+	for _, graph := range []*hnsw.Config{nil, {}} {
+		db, _ = New[Embedded](&Config{
+			DefaultLanguage: tokenizer.ENGLISH,
+			TokenizerConfig: &tokenizer.Config{},
+			HNSW:            graph,
+		})
+		for _, doc := range documents {
+			_, err := db.Insert(&InsertParams[Embedded]{Document: doc})
+			assert.NoError(t, err)
+		}
+
+		cases := []TestCase[SearchParams, []Embedded]{
+			{
+				given: SearchParams{
+					Vector: &VectorParams{Property: "embedding", Value: []float32{1, 0.2, 0}, K: 2},
+				},
+				expected: []Embedded{documents[0], documents[1]},
+			},
+			{
+				given: SearchParams{
+					Vector: &VectorParams{Property: "embedding", Value: []float32{0, 0.1, 1}},
+				},
+				expected: []Embedded{documents[3], documents[2], documents[1], documents[0]},
+			},
+			{
+				given: SearchParams{
+					Vector:  &VectorParams{Property: "embedding", Value: []float32{1, 0.2, 0}, K: 1},
+					Filters: Filters{Term: map[string]any{"category": "b"}},
+				},
+				expected: []Embedded{documents[1]},
+			},
+			{
+				given: SearchParams{
+					Vector: &VectorParams{Property: "location", Value: []float32{9, 1}},
+				},
+				expected: []Embedded{documents[2], documents[1], documents[0]},
+			},
+		}
+		for _, c := range cases {
+			t.Run(fmt.Sprintf("hnsw=%t/%v", graph != nil, c.given.Vector), func(t *testing.T) {
+				c.given.Limit = 10
+
+				actual, err := db.Search(&c.given)
+				assert.NoError(t, err)
+				assert.Equal(t, len(c.expected), len(actual.Hits))
+
+				for i, doc := range c.expected {
+					assert.Equal(t, doc, actual.Hits[i].Data)
+				}
+			})
+		}
+
+		actual, _ := db.Search(&SearchParams{
+			Vector: &VectorParams{Property: "location", Value: []float32{10, 0}, K: 1},
+			Limit:  10,
+		})
+		assert.Equal(t, 1.0, actual.Hits[0].Score)
+	}
+
+	_, err := db.Insert(&InsertParams[Embedded]{Document: Embedded{Embedding: []float32{1, 2}}})
+	assert.Equal(t, &VectorDimensionError{Property: "embedding", Expected: 3, Actual: 2}, err)
+
+	_, err = db.Search(&SearchParams{Vector: &VectorParams{Property: "embedding", Value: []float32{1}}})
+	assert.Equal(t, &VectorDimensionError{Property: "embedding", Expected: 3, Actual: 1}, err)
+
+	_, err = db.Search(&SearchParams{Vector: &VectorParams{Property: "title", Value: []float32{1}}})
+	assert.Equal(t, &WrongVectorPropertyType{Property: "title"}, err)
+
+}
+
+func TestSearchHybrid(t *testing.T) {
+	documents := []Embedded{
+		{Title: "North", Category: "a", Embedding: []float32{1, 0, 0}},
+		{Title: "North east", Category: "b", Embedding: []float32{1, 1, 0}},
+		{Title: "East", Category: "a", Embedding: []float32{0, 1, 0}},
+		{Title: "Up", Category: "b", Embedding: []float32{0, 0, 1}},
+	}
+
+	db, _ := New[Embedded](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	for _, doc := range documents {
+		_, err := db.Insert(&InsertParams[Embedded]{Document: doc})
+		assert.NoError(t, err)
+	}
+
+	vector := &VectorParams{Property: "embedding", Value: []float32{0.2, 1, 0}, K: 3}
+	number := func(value float64) *float64 { return &value }
+
+	cases := []TestCase[SearchParams, []Embedded]{
+		{
+			given:    SearchParams{Query: "north", Vector: vector},
+			expected: []Embedded{documents[0], documents[1], documents[2]},
+		},
+		{
+			given:    SearchParams{Query: "north", Vector: vector, Hybrid: HybridParams{Fusion: WEIGHTED, Alpha: number(0.8)}},
+			expected: []Embedded{documents[2], documents[1], documents[0]},
+		},
+		{
+			given:    SearchParams{Query: "north", Vector: vector, Hybrid: HybridParams{Fusion: WEIGHTED, Alpha: number(0.2)}},
+			expected: []Embedded{documents[0], documents[2], documents[1]},
+		},
+		{
+			given: SearchParams{
+				Query:   "north",
+				Vector:  vector,
+				Filters: Filters{Term: map[string]any{"category": "b"}},
+			},
+			expected: []Embedded{documents[1], documents[3]},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given.Hybrid), func(t *testing.T) {
+			c.given.Relevance = BM25Params{K: 1.2, B: 0.75, D: 0.5}
+			c.given.Limit = 10
+
+			actual, err := db.Search(&c.given)
+			assert.NoError(t, err)
+			assert.Equal(t, len(c.expected), len(actual.Hits))
+
+			for i, doc := range c.expected {
+				assert.Equal(t, doc, actual.Hits[i].Data)
+			}
+		})
+	}
+
+	actual, _ := db.Search(&SearchParams{
+		Query:     "north",
+		Vector:    vector,
+		Relevance: BM25Params{K: 1.2, B: 0.75, D: 0.5},
+		Limit:     10,
+		Explain:   true,
+	})
+	hit := actual.Hits[0]
+	assert.InDelta(t, 1.0/61+1.0/63, hit.Score, 1e-9)
+	assert.Equal(t, 1, hit.Hybrid.TextRank)
+	assert.Equal(t, 3, hit.Hybrid.VectorRank)
+	assert.InDelta(t, lib.CosineSimilarity(vector.Value, documents[0].Embedding), hit.Hybrid.Vector, 1e-9)
+	assert.Equal(t, "rrf fusion of", hit.Explanation.Description)
+	assert.Len(t, hit.Explanation.Details, 2)
+
+	actual, _ = db.Search(&SearchParams{Query: "up", Limit: 10})
+	assert.Nil(t, actual.Hits[0].Hybrid)
+
+	// the weighted fusion blends both sides equally by default
+	relevance := BM25Params{K: 1.2, B: 0.75, D: 0.5}
+	weighted, _ := db.Search(&SearchParams{Query: "north", Vector: vector, Hybrid: HybridParams{Fusion: WEIGHTED}, Relevance: relevance, Limit: 10})
+	balanced, _ := db.Search(&SearchParams{Query: "north", Vector: vector, Hybrid: HybridParams{Fusion: WEIGHTED, Alpha: number(0.5)}, Relevance: relevance, Limit: 10})
+	assert.Equal(t, balanced.Hits, weighted.Hits)
+	assert.Greater(t, weighted.Hits[len(weighted.Hits)-1].Score, 0.0)
+
+	// an alpha of 0 only ranks by the text scores, and an alpha of 1 only by the vector scores
+	lexical, _ := db.Search(&SearchParams{Query: "north", Vector: vector, Hybrid: HybridParams{Fusion: WEIGHTED, Alpha: number(0)}, Relevance: relevance, Limit: 10})
+	assert.Equal(t, documents[0], lexical.Hits[0].Data)
+	assert.Equal(t, 1.0, lexical.Hits[0].Score)
+	for _, hit := range lexical.Hits[1:] {
+		assert.Zero(t, hit.Score)
+	}
+
+	semantic, _ := db.Search(&SearchParams{Query: "north", Vector: vector, Hybrid: HybridParams{Fusion: WEIGHTED, Alpha: number(1)}, Relevance: relevance, Limit: 10})
+	assert.Equal(t, []Embedded{documents[2], documents[1], documents[0]}, []Embedded{semantic.Hits[0].Data, semantic.Hits[1].Data, semantic.Hits[2].Data})
+	assert.Equal(t, 1.0, semantic.Hits[0].Score)
+	assert.Zero(t, semantic.Hits[2].Score)
+
+	_, err := db.Search(&SearchParams{Query: "north", Vector: vector, Hybrid: HybridParams{Fusion: "max"}})
+	assert.Equal(t, &FusionNotSupportedError{Fusion: "max"}, err)
+
+	_, err = db.Search(&SearchParams{Query: "north", Vector: vector, Hybrid: HybridParams{Fusion: WEIGHTED, Alpha: number(1.5)}})
+	assert.Equal(t, &InvalidSearchParamsError{Reason: "alpha must be between 0 and 1"}, err)
+}
+
+func TestSearchVectorHNSW(t *testing.T) {
+	config := &Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+		HNSW:            &hnsw.Config{M: 8},
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	randomVector := func() []float32 {
+		return []float32{rng.Float32(), rng.Float32(), rng.Float32()}
+	}
+
+	db, _ := New[Embedded](config)
+	ids := make([]string, 0, 300)
+	for i := 0; i < 300; i++ {
+		doc, _ := db.Insert(&InsertParams[Embedded]{Document: Embedded{Embedding: randomVector()}})
+		ids = append(ids, doc.Id)
+	}
+	for _, id := range ids[:100] {
+		assert.NoError(t, db.Delete(&DeleteParams[Embedded]{Id: id}))
+	}
+	for _, id := range ids[100:150] {
+		_, err := db.Update(&UpdateParams[Embedded]{Id: id, Document: Embedded{Embedding: randomVector()}})
+		assert.NoError(t, err)
+	}
+
+	vectors := db.index.vectors["embedding"]
+	assert.Equal(t, 200, vectors.graph.Len())
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, db.Save(&buf))
+	loaded, err := Load[Embedded](&buf, config)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, loaded.index.vectors["embedding"].graph.Len())
+
+	for i := 0; i < 20; i++ {
+		query := randomVector()
+		params := &SearchParams{
+			Vector: &VectorParams{Property: "embedding", Value: query, K: 5, Ef: 100},
+			Limit:  5,
+		}
+
+		actual, _ := db.Search(params)
+		expected := vectors.scan(query, 5, nil)
+		assert.Equal(t, len(expected), len(actual.Hits))
+		for j, m := range expected {
+			assert.Equal(t, m.id, actual.Hits[j].Id)
+		}
+
+		reloaded, _ := loaded.Search(params)
+		assert.Equal(t, actual.Hits, reloaded.Hits)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	db, _ := New[Document](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{EnableStemming: true, EnableStopWords: true},
+	})
+	for _, doc := range []Document{
+		{Title: "The Silicon Brain", Abstract: "Brains built from silicon chips"},
+		{Title: "Silica gel", Abstract: "Silica keeps things dry"},
+		{Title: "Brain surgery", Abstract: "A brief history of surgery"},
+		{Title: "Silicon valley", Abstract: "Startups and brainstorming"},
+	} {
+		_, _ = db.Insert(&InsertParams[Document]{Document: doc})
+	}
+
+	cases := []TestCase[SuggestParams, []Suggestion]{
+		{
+			given: SuggestParams{Query: "sil"},
+			expected: []Suggestion{
+				{Text: "silicon", Term: "silicon", Count: 2},
+				{Text: "silica", Term: "silica", Count: 1},
+			},
+		},
+		{
+			given: SuggestParams{Query: "Brain sil"},
+			expected: []Suggestion{
+				{Text: "Brain silicon", Term: "silicon", Count: 1},
+			},
+		},
+		{
+			given: SuggestParams{Query: "the silic", Limit: 1},
+			expected: []Suggestion{
+				{Text: "the silicon", Term: "silicon", Count: 2},
+			},
+		},
+		{
+			given: SuggestParams{Query: "brains"},
+			expected: []Suggestion{
+				{Text: "brain", Term: "brain", Count: 2},
+				{Text: "brainstorming", Term: "brainstorm", Count: 1},
+			},
+		},
+		{
+			given: SuggestParams{Query: "slic", Tolerance: 1},
+			expected: []Suggestion{
+				{Text: "silicon", Term: "silicon", Count: 2, Distance: 1},
+				{Text: "silica", Term: "silica", Count: 1, Distance: 1},
+			},
+		},
+		{
+			given:    SuggestParams{Query: "x", Tolerance: 1},
+			expected: []Suggestion{},
+		},
+		{
+			given: SuggestParams{Query: "sur", Properties: []string{"title"}},
+			expected: []Suggestion{
+				{Text: "surgery", Term: "surgeri", Count: 1},
+			},
+		},
+		{
+			given:    SuggestParams{Query: "chips sil"},
+			expected: []Suggestion{{Text: "chips silicon", Term: "silicon", Count: 1}},
+		},
+		{
+			given:    SuggestParams{Query: "gel brain"},
+			expected: []Suggestion{},
+		},
+		{
+			given:    SuggestParams{Query: " "},
+			expected: []Suggestion{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given.Query, func(t *testing.T) {
+			actual, err := db.Suggest(&c.given)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+
+	_, err := db.Suggest(&SuggestParams{Query: "sil", Properties: []string{"url"}})
+	assert.Equal(t, &WrongSearchPropertyType{Property: "url"}, err)
+}
+
+func TestSearchSpelling(t *testing.T) {
+	db, _ := New[Document](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{EnableStemming: true, EnableStopWords: true},
+	})
+	for _, doc := range []Document{
+		{Title: "The Silicon Brain", Abstract: "Brains built from silicon chips"},
+		{Title: "Silica gel", Abstract: "Silica keeps things dry"},
+		{Title: "Brain surgery", Abstract: "A brief history of surgery"},
+		{Title: "Silicon valley", Abstract: "Startups and brainstorming"},
+	} {
+		_, _ = db.Insert(&InsertParams[Document]{Document: doc})
+	}
+
+	type result struct {
+		didYouMean string
+		corrected  bool
+		count      int
+	}
+
+	cases := []TestCase[SearchParams, result]{
+		{
+			given:    SearchParams{Query: "silicom brian", Spelling: &SpellingParams{}},
+			expected: result{didYouMean: "silicon brain"},
+		},
+		{
+			given:    SearchParams{Query: "silicom brian", Spelling: &SpellingParams{AutoCorrect: true}},
+			expected: result{didYouMean: "silicon brain", corrected: true, count: 3},
+		},
+		{
+			given:    SearchParams{Query: "silicon brain", Spelling: &SpellingParams{AutoCorrect: true}},
+			expected: result{count: 3},
+		},
+		{
+			given:    SearchParams{Query: `"Slica gel" OR surgary`, Spelling: &SpellingParams{AutoCorrect: true}},
+			expected: result{didYouMean: `"silica gel" OR surgery`, corrected: true, count: 2},
+		},
+		{
+			given:    SearchParams{Query: "title:valey", Spelling: &SpellingParams{}},
+			expected: result{didYouMean: "title:valley"},
+		},
+		{
+			given:    SearchParams{Query: "siliconn", Spelling: &SpellingParams{MaxDistance: 1}},
+			expected: result{didYouMean: "silicon"},
+		},
+		{
+			given:    SearchParams{Query: "slcn", Spelling: &SpellingParams{MaxDistance: 1}},
+			expected: result{},
+		},
+		{
+			given:    SearchParams{Query: "silica", Spelling: &SpellingParams{MinFrequency: 3}},
+			expected: result{didYouMean: "silicon", count: 1},
+		},
+		{
+			given:    SearchParams{Query: "silicom"},
+			expected: result{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given.Query, func(t *testing.T) {
+			actual, err := db.Search(&c.given)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, result{didYouMean: actual.DidYouMean, corrected: actual.Corrected, count: actual.Count})
+		})
+	}
+}
+
+func TestScorers(t *testing.T) {
+	field := FieldStats{
+		Property:          "title",
+		TermFrequency:     0.5,
+		DocumentFrequency: 3,
+		FieldLength:       4,
+		AvgFieldLength:    5,
+		Boost:             1,
+	}
+	relevance := BM25Params{K: 1.2, B: 0.75, D: 0.5}
+
+	single := &ScoreParams{Fields: []FieldStats{field}, DocsCount: 10, Relevance: relevance}
+	bm25 := (&BM25Scorer{}).Score(single)
+
+	// BM25F reduces to BM25 for a single field
+	assert.InDelta(t, bm25, (&BM25FScorer{}).Score(single), 1e-9)
+	assert.Greater(t, (&BM25PlusScorer{}).Score(single), bm25)
+	assert.Equal(t, 1.0, (&ConstantScorer{}).Score(single))
+
+	title, abstract := field, field
+	abstract.Property = "abstract"
+	abstract.Boost = 2
+	multiple := &ScoreParams{Fields: []FieldStats{title, abstract}, DocsCount: 10, Relevance: relevance}
+
+	assert.InDelta(t, 3*bm25, (&BM25Scorer{}).Score(multiple), 1e-9)
+	assert.Less(t, (&BM25FScorer{}).Score(multiple), 3*bm25)
+	assert.InDelta(t, 3*(&TFIDFScorer{}).Score(single), (&TFIDFScorer{}).Score(multiple), 1e-9)
+	assert.Equal(t, 3.0, (&ConstantScorer{}).Score(multiple))
+}
+
+func TestSearchScoring(t *testing.T) {
+	db, _ := New[User](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+		DefaultScoring:  CONSTANT,
+	})
+	db.InsertBatch(&InsertBatchParams[User]{
+		Documents: testData,
+		BatchSize: 3,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	actual, err := db.Search(&SearchParams{
+		Query: "tom brown",
+		Limit: 10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, actual.Count)
+	assert.Equal(t, testData[4], actual.Hits[0].Data)
+	assert.Equal(t, 3.0, actual.Hits[0].Score)
+
+	actual, err = db.Search(&SearchParams{
+		Query:     "tom brown",
+		Scoring:   BM25F,
+		Relevance: BM25Params{K: 1.2, B: 0.75},
+		Limit:     10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, actual.Count)
+	assert.Equal(t, testData[4], actual.Hits[0].Data)
+
+	_, err = db.Search(&SearchParams{
+		Query:   "tom brown",
+		Scoring: "pagerank",
+	})
+	assert.Equal(t, &ScoringNotSupportedError{Scoring: "pagerank"}, err)
+
+	_, err = New[User](&Config{DefaultScoring: "pagerank"})
+	assert.Equal(t, &ScoringNotSupportedError{Scoring: "pagerank"}, err)
+}
+
+func TestSearchAnalyzer(t *testing.T) {
+	british := map[string]string{"colour": "color", "grey": "gray"}
+	db, err := New[Document](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{EnableStemming: true, EnableStopWords: true},
+		Analyzers: map[string]*tokenizer.Analyzer{
+			"american": {
+				CharFilters: []tokenizer.CharFilter{tokenizer.Lowercase},
+				TokenFilters: []tokenizer.TokenFilter{tokenizer.TokenFilterFunc(func(token string) string {
+					if spelling, ok := british[token]; ok {
+						return spelling
+					}
+					return token
+				})},
+			},
+		},
+		DefaultAnalyzer: "american",
+	})
+	assert.NoError(t, err)
+
+	for _, doc := range []Document{
+		{Title: "The colour grey"},
+		{Title: "Gray colors"},
+		{Title: "The color of the sky"},
+	} {
+		_, _ = db.Insert(&InsertParams[Document]{Document: doc})
+	}
+
+	cases := []TestCase[string, []string]{
+		{given: "colour", expected: []string{"The colour grey", "The color of the sky"}},
+		{given: "GREY", expected: []string{"Gray colors", "The colour grey"}},
+		{given: "colors", expected: []string{"Gray colors"}},
+		{given: "the", expected: []string{"The color of the sky", "The colour grey"}},
+	}
+	for _, c := range cases {
+		t.Run(c.given, func(t *testing.T) {
+			result, err := db.Search(&SearchParams{
+				Query:      c.given,
+				Properties: []string{"title"},
+				Exact:      true,
+				Limit:      10,
+			})
+			assert.NoError(t, err)
+
+			titles := make([]string, len(result.Hits))
+			for i, hit := range result.Hits {
+				titles[i] = hit.Data.Title
+			}
+			assert.ElementsMatch(t, c.expected, titles)
+		})
+	}
+
+	_, err = New[Document](&Config{DefaultAnalyzer: "american"})
+	assert.Equal(t, &AnalyzerNotSupportedError{Analyzer: "american"}, err)
+}
+
+func TestSearchFieldAnalyzers(t *testing.T) {
+	db, _ := New[Listing](&Config{
+		DefaultLanguage: tokenizer.FRENCH,
+		TokenizerConfig: &tokenizer.Config{EnableStemming: true, EnableStopWords: true},
+	})
+	listings := []Listing{
+		{Title: "Running shoes", Sku: "RS-100 X", Description: "Shoes for the running track"},
+		{Title: "Trail runner", Sku: "TR-200", Description: "Runs on every trail"},
+	}
+	ids := make([]string, len(listings))
+	for i, listing := range listings {
+		record, _ := db.Insert(&InsertParams[Listing]{Document: listing, Language: tokenizer.ENGLISH})
+		ids[i] = record.Id
+	}
+
+	cases := []TestCase[SearchParams, []Listing]{
+		{
+			given:    SearchParams{Query: "runs"},
+			expected: []Listing{listings[0], listings[1]},
+		},
+		{
+			given:    SearchParams{Query: "runs", Properties: []string{"title"}},
+			expected: []Listing{listings[0]},
+		},
+		{
+			given:    SearchParams{Query: "running", Properties: []string{"description"}},
+			expected: []Listing{listings[0]},
+		},
+		{
+			given:    SearchParams{Query: "the"},
+			expected: []Listing{listings[0]},
+		},
+		{
+			given:    SearchParams{Query: "TR-200"},
+			expected: []Listing{listings[1]},
+		},
+		{
+			given:    SearchParams{Query: `sku:"rs-100 x"`},
+			expected: []Listing{listings[0]},
+		},
+		{
+			given:    SearchParams{Query: "sku:rs"},
+			expected: []Listing{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given.Query, func(t *testing.T) {
+			c.given.Exact = true
+			c.given.Limit = 10
+			result, err := db.Search(&c.given)
+			assert.NoError(t, err)
+
+			actual := make([]Listing, len(result.Hits))
+			for i, hit := range result.Hits {
+				actual[i] = hit.Data
+			}
+			assert.ElementsMatch(t, c.expected, actual)
+		})
+	}
+
+	// the deletes remove every token indexed by the field analyzers
+	for _, id := range ids {
+		err := db.Delete(&DeleteParams[Listing]{Id: id})
+		assert.NoError(t, err)
+	}
+	for _, occurrences := range db.index.tokenOccurrences {
+		assert.Empty(t, occurrences)
+	}
+
+	type UnknownAnalyzer struct {
+		Title string `index:"title,analyzer=klingon"`
+	}
+	_, err := New[UnknownAnalyzer](&Config{})
+	assert.Equal(t, &AnalyzerNotSupportedError{Analyzer: "klingon"}, err)
+}
+
+func TestParseSchema(t *testing.T) {
+	type InvalidBoost struct {
+		Title string `index:"title,boost=high"`
+	}
+
+	type UnknownOption struct {
+		Title string `index:"title,fancy"`
+	}
+
+	type InvalidKeyword struct {
+		Year int `index:"year,keyword"`
+	}
+
+	type UnsupportedType struct {
+		Metadata map[string]string `index:"metadata"`
+	}
+
+	type InvalidVector struct {
+		Embedding []float64 `index:"embedding,vector,dims=3"`
+	}
+
+	type MissingDims struct {
+		Embedding []float32 `index:"embedding,vector"`
+	}
+
+	type UnknownMetric struct {
+		Embedding []float32 `index:"embedding,vector,dims=3,metric=manhattan"`
+	}
+
+	type InvalidStem struct {
+		Title string `index:"title,stem=sometimes"`
+	}
+
+	type InvalidAnalyzer struct {
+		Year int `index:"year,analyzer=keyword"`
+	}
+
+	type InvalidLanguage struct {
+		Year int `index:"year,language"`
+	}
+
+	type DuplicateLanguage struct {
+		Language string `index:"lang,language"`
+		Locale   string `index:"locale,language"`
+	}
+
+	type KeywordLanguage struct {
+		Language string `index:"lang,keyword,language"`
+	}
+
+	type LanguageKeyword struct {
+		Language string `index:"lang,language,keyword"`
+	}
+
+	properties, err := parseSchema(reflect.TypeOf(BoostedDocument{}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*property{
+		"title":    {name: "title", boost: 2},
+		"abstract": {name: "abstract", boost: 1},
+	}, properties)
+
+	_, err = parseSchema(reflect.TypeOf(InvalidBoost{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Title", Tag: "title,boost=high", Reason: "boost must be a non-negative number"}, err)
+
+	_, err = New[UnknownOption](&Config{})
+	assert.Equal(t, &InvalidIndexTagError{Field: "Title", Tag: "title,fancy", Reason: "unknown option 'fancy'"}, err)
+
+	_, err = parseSchema(reflect.TypeOf(InvalidKeyword{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Year", Tag: "year,keyword", Reason: "keyword is only supported on string fields"}, err)
+
+	_, err = New[UnsupportedType](&Config{})
+	assert.Equal(t, &UnsupportedFieldTypeError{Field: "Metadata", Type: "map[string]string"}, err)
+
+	_, err = parseSchema(reflect.TypeOf(InvalidVector{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Embedding", Tag: "embedding,vector,dims=3", Reason: "vector is only supported on []float32 fields"}, err)
+
+	_, err = parseSchema(reflect.TypeOf(MissingDims{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Embedding", Tag: "embedding,vector", Reason: "vector requires dims"}, err)
+
+	_, err = parseSchema(reflect.TypeOf(UnknownMetric{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Embedding", Tag: "embedding,vector,dims=3,metric=manhattan", Reason: "unknown metric 'manhattan'"}, err)
+
+	_, err = parseSchema(reflect.TypeOf(InvalidStem{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Title", Tag: "title,stem=sometimes", Reason: "stem must be a boolean"}, err)
+
+	_, err = parseSchema(reflect.TypeOf(InvalidAnalyzer{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Year", Tag: "year,analyzer=keyword", Reason: "analyzer, stem and stopwords are only supported on text fields"}, err)
+
+	_, err = parseSchema(reflect.TypeOf(InvalidLanguage{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Year", Tag: "year,language", Reason: "language is only supported on string fields"}, err)
+
+	_, err = parseSchema(reflect.TypeOf(DuplicateLanguage{}))
+	assert.Equal(t, &InvalidIndexTagError{Field: "Locale", Tag: "locale,language", Reason: "only one field can hold the language"}, err)
+
+	properties, err = parseSchema(reflect.TypeOf(Review{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &property{name: "lang", kind: keywordProperty, boost: 1, language: true}, properties["lang"])
+
+	// the options are applied in order, and the outcome does not depend on it
+	for i := 0; i < 100; i++ {
+		for _, schema := range []reflect.Type{reflect.TypeOf(KeywordLanguage{}), reflect.TypeOf(LanguageKeyword{})} {
+			properties, err = parseSchema(schema)
+			assert.NoError(t, err)
+			assert.Equal(t, &property{name: "lang", kind: keywordProperty, boost: 1, language: true}, properties["lang"])
+		}
+	}
+
+	properties, err = parseSchema(reflect.TypeOf(Listing{}))
+	assert.NoError(t, err)
+	stem, stopWords := false, false
+	assert.Equal(t, map[string]*property{
+		"title":       {name: "title", kind: textProperty, boost: 1},
+		"sku":         {name: "sku", kind: textProperty, boost: 1, analyzer: KEYWORD},
+		"description": {name: "description", kind: textProperty, boost: 1, analyzer: "en", stem: &stem, stopWords: &stopWords},
+	}, properties)
+
+	properties, err = parseSchema(reflect.TypeOf(Embedded{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &property{name: "embedding", kind: vectorProperty, boost: 1, dims: 3, metric: COSINE}, properties["embedding"])
+	assert.Equal(t, &property{name: "location", kind: vectorProperty, boost: 1, dims: 2, metric: L2}, properties["location"])
+
+	properties, err = parseSchema(reflect.TypeOf(Article{}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*property{
+		"title":         {name: "title", kind: textProperty, boost: 1},
+		"subtitle":      {name: "subtitle", kind: textProperty, boost: 1},
+		"paragraphs":    {name: "paragraphs", kind: textProperty, boost: 1},
+		"tags":          {name: "tags", kind: keywordProperty, boost: 1},
+		"ratings":       {name: "ratings", kind: numberProperty, boost: 1},
+		"authors.name":  {name: "authors.name", kind: textProperty, boost: 1},
+		"authors.email": {name: "authors.email", kind: textProperty, boost: 1},
+		"editor.name":   {name: "editor.name", kind: textProperty, boost: 1},
+		"editor.email":  {name: "editor.email", kind: textProperty, boost: 1},
+		"published":     {name: "published", kind: numberProperty, boost: 1},
+	}, properties)
+
+	properties, err = parseSchema(reflect.TypeOf(Product{}))
+	assert.NoError(t, err)
+	assert.Equal(t, keywordProperty, properties["category"].kind)
+	assert.Equal(t, textProperty, properties["name"].kind)
+	assert.Equal(t, boolProperty, properties["available"].kind)
+	assert.Equal(t, numberProperty, properties["price"].kind)
+}
+
+func TestParseQuery(t *testing.T) {
+	cases := []TestCase[string, query]{
+		{
+			given:    "brain",
+			expected: &termQuery{text: "brain"},
+		},
+		{
+			given: "brain cortex",
+			expected: &booleanQuery{
+				should: []query{&termQuery{text: "brain"}, &termQuery{text: "cortex"}},
+			},
+		},
+		{
+			given: "brain AND (cortex OR neuron) -mouse",
+			expected: &booleanQuery{
+				should: []query{
+					&booleanQuery{
+						must: []query{
+							&termQuery{text: "brain"},
+							&booleanQuery{
+								should: []query{&termQuery{text: "cortex"}, &termQuery{text: "neuron"}},
+							},
+						},
+					},
+				},
+				mustNot: []query{&termQuery{text: "mouse"}},
+			},
+		},
+		{
+			given: "+brain-busting NOT mouse",
+			expected: &booleanQuery{
+				must:    []query{&termQuery{text: "brain-busting"}},
+				mustNot: []query{&termQuery{text: "mouse"}},
+			},
+		},
+		{
+			given: "NOT mouse",
+			expected: &booleanQuery{
+				mustNot: []query{&termQuery{text: "mouse"}},
+			},
+		},
+		{
+			given: `title:brain author.name:(mic OR tom) -abstract:"mouse brain"`,
+			expected: &booleanQuery{
+				should: []query{
+					&fieldQuery{field: "title", query: &termQuery{text: "brain"}},
+					&fieldQuery{
+						field: "author.name",
+						query: &booleanQuery{
+							should: []query{&termQuery{text: "mic"}, &termQuery{text: "tom"}},
+						},
+					},
+				},
+				mustNot: []query{&fieldQuery{field: "abstract", query: &phraseQuery{text: "mouse brain"}}},
+			},
+		},
+		{
+			given: `"silicon brain"~3 -"mouse brain"`,
+			expected: &booleanQuery{
+				should:  []query{&phraseQuery{text: "silicon brain", slop: 3}},
+				mustNot: []query{&phraseQuery{text: "mouse brain"}},
+			},
+		},
+		{
+			given: "brain NOT -mouse --rat ++cortex",
+			expected: &booleanQuery{
+				should:  []query{&termQuery{text: "brain"}},
+				must:    []query{&termQuery{text: "cortex"}},
+				mustNot: []query{&termQuery{text: "mouse"}, &termQuery{text: "rat"}},
+			},
+		},
+		{
+			given: "see http://example.com",
+			expected: &booleanQuery{
+				should: []query{&termQuery{text: "see"}, &termQuery{text: "http://example.com"}},
+			},
+		},
+		{
+			given: "meeting at 10:30",
+			expected: &booleanQuery{
+				should: []query{&termQuery{text: "meeting"}, &termQuery{text: "at"}, &termQuery{text: "10:30"}},
+			},
+		},
+		{
+			given: "screen 16:9 title:wide",
+			expected: &booleanQuery{
+				should: []query{
+					&termQuery{text: "screen"},
+					&termQuery{text: "16:9"},
+					&fieldQuery{field: "title", query: &termQuery{text: "wide"}},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given, func(t *testing.T) {
+			actual, err := parseQuery(c.given)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	cases := []TestCase[string, error]{
+		{
+			given:    "(brain",
+			expected: &QuerySyntaxError{Query: "(brain", Position: 6, Reason: "missing ')'"},
+		},
+		{
+			given:    "brain)",
+			expected: &QuerySyntaxError{Query: "brain)", Position: 5, Reason: "unexpected ')'"},
+		},
+		{
+			given:    "brain AND",
+			expected: &QuerySyntaxError{Query: "brain AND", Position: 9, Reason: "unexpected end of query"},
+		},
+		{
+			given:    "OR brain",
+			expected: &QuerySyntaxError{Query: "OR brain", Position: 0, Reason: "missing operand before 'OR'"},
+		},
+		{
+			given:    `brain "silicon`,
+			expected: &QuerySyntaxError{Query: `brain "silicon`, Position: 6, Reason: "missing closing quote"},
+		},
+		{
+			given:    `"silicon brain"~`,
+			expected: &QuerySyntaxError{Query: `"silicon brain"~`, Position: 15, Reason: "invalid phrase slop"},
+		},
+		{
+			given:    "brain ()",
+			expected: &QuerySyntaxError{Query: "brain ()", Position: 7, Reason: "empty query"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.given, func(t *testing.T) {
+			_, err := parseQuery(c.given)
+			assert.Equal(t, c.expected, err)
+		})
+	}
+}
+
+func TestDocumentLanguage(t *testing.T) {
+	db, _ := New[Review](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{EnableStemming: true, EnableStopWords: true},
+	})
+
+	french, _ := db.Insert(&InsertParams[Review]{Document: Review{Text: "Les chats mangeaient", Language: "FR"}})
+	english, _ := db.Insert(&InsertParams[Review]{Document: Review{Text: "The cats were eating"}})
+	spanish, _ := db.Insert(&InsertParams[Review]{Document: Review{Text: "Los gatos comían"}, Language: tokenizer.SPANISH})
+	assert.Equal(t, map[string]tokenizer.Language{
+		french.Id:  tokenizer.FRENCH,
+		english.Id: tokenizer.ENGLISH,
+		spanish.Id: tokenizer.SPANISH,
+	}, db.languages)
+
+	_, err := db.Insert(&InsertParams[Review]{Document: Review{Text: "Kotki", Language: "xx"}})
+	assert.Equal(t, &tokenizer.LanguageNotSupportedError{Language: "xx"}, err)
+
+	// the updates keep the language of the document unless a new one is given
+	_, err = db.Update(&UpdateParams[Review]{Id: spanish.Id, Document: Review{Text: "Los gatos comen"}})
+	assert.NoError(t, err)
+	_, err = db.Update(&UpdateParams[Review]{Id: french.Id, Document: Review{Text: "The cats ate", Language: "en"}})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.SPANISH, db.languages[spanish.Id])
+	assert.Equal(t, tokenizer.ENGLISH, db.languages[french.Id])
+	assert.NotContains(t, db.index.tokenOccurrences["text"], "mang")
+
+	result, err := db.Search(&SearchParams{
+		Filters: Filters{Term: map[string]any{"lang": "en"}},
+		Limit:   10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Count)
+	assert.Equal(t, french.Id, result.Hits[0].Id)
+
+	for _, id := range []string{french.Id, english.Id, spanish.Id} {
+		assert.NoError(t, db.Delete(&DeleteParams[Review]{Id: id}))
+	}
+	assert.Empty(t, db.index.tokenOccurrences["text"])
+	assert.Empty(t, db.languages)
+
+	_, err = New[Review](&Config{})
+	assert.Equal(t, &tokenizer.LanguageNotSupportedError{Language: ""}, err)
+
+	_, err = New[Review](&Config{DefaultLanguage: "xx"})
+	assert.Equal(t, &tokenizer.LanguageNotSupportedError{Language: "xx"}, err)
+}
+
+func TestAutoLanguage(t *testing.T) {
+	db, _ := New[Review](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{EnableStemming: true, EnableStopWords: true},
+	})
+
+	french, err := db.Insert(&InsertParams[Review]{
+		Document: Review{Text: "Les chercheurs étudient les chats sauvages dans les forêts du pays."},
+		Language: tokenizer.AUTO,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.FRENCH, french.Language)
+	assert.Greater(t, french.Confidence, 0.5)
+
+	spanish, err := db.Insert(&InsertParams[Review]{
+		Document: Review{Text: "Los investigadores estudian los gatos salvajes en los bosques del país.", Language: "auto"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.SPANISH, spanish.Language)
+
+	// nothing to detect in a document without letters
+	empty, err := db.Insert(&InsertParams[Review]{Document: Review{Text: "42"}, Language: tokenizer.AUTO})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.ENGLISH, empty.Language)
+	assert.Zero(t, empty.Confidence)
+
+	assert.Equal(t, map[string]tokenizer.Language{
+		french.Id:  tokenizer.FRENCH,
+		spanish.Id: tokenizer.SPANISH,
+		empty.Id:   tokenizer.ENGLISH,
+	}, db.languages)
+
+	updated, err := db.Update(&UpdateParams[Review]{
+		Id:       empty.Id,
+		Document: Review{Text: "The researchers are studying wild cats in the forests of the country."},
+		Language: tokenizer.AUTO,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.ENGLISH, updated.Language)
+
+	result, err := db.Search(&SearchParams{
+		Query:    "text:chercheurs sauvages dans les forêts",
+		Language: tokenizer.AUTO,
+		Limit:    10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.FRENCH, result.Language)
+	assert.Greater(t, result.Confidence, 0.5)
+	assert.Equal(t, 1, result.Count)
+	assert.Equal(t, french.Id, result.Hits[0].Id)
+
+	// a few words are not enough to detect a language
+	result, err = db.Search(&SearchParams{Query: "chercheurs sauvages", Language: tokenizer.AUTO, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.ENGLISH, result.Language)
+	assert.Zero(t, result.Confidence)
+
+	result, err = db.Search(&SearchParams{Query: "chats", Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.ENGLISH, result.Language)
+	assert.Zero(t, result.Confidence)
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	language := tokenizer.Language("x-store")
+	err := tokenizer.RegisterLanguage(language, &tokenizer.LanguageParams{SplitRule: regexp.MustCompile(`[^a-z]+`)})
+	assert.NoError(t, err)
+
+	db, _ := New[Review](&Config{DefaultLanguage: tokenizer.ENGLISH})
+	_, err = db.Insert(&InsertParams[Review]{Document: Review{Text: "well-known"}, Language: language})
+	assert.NoError(t, err)
+
+	result, err := db.Search(&SearchParams{Query: "known", Language: language, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Count)
+
+	// the cached analyzers follow a language registered again
+	err = tokenizer.RegisterLanguage(language, &tokenizer.LanguageParams{SplitRule: regexp.MustCompile(`[^a-z-]+`)})
+	assert.NoError(t, err)
+	_, err = db.Insert(&InsertParams[Review]{Document: Review{Text: "well-made"}, Language: language})
+	assert.NoError(t, err)
+
+	result, err = db.Search(&SearchParams{Query: "made", Language: language, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Count)
+
+	result, err = db.Search(&SearchParams{Query: "well-made", Language: language, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Count)
+}
+
+func TestSaveLoad(t *testing.T) {
+	config := &Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	}
+
+	db, _ := New[User](config)
+	db.InsertBatch(&InsertBatchParams[User]{
+		Documents: testData,
+		BatchSize: 3,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, db.Save(&buf))
+
+	loaded, err := Load[User](&buf, config)
+	assert.NoError(t, err)
+
+	assert.Equal(t, db.documents, loaded.documents)
+	assert.Equal(t, db.languages, loaded.languages)
+	assert.Equal(t, db.index.avgFieldLength, loaded.index.avgFieldLength)
+	assert.Equal(t, db.index.fieldLengths, loaded.index.fieldLengths)
+	assert.Equal(t, db.index.tokenOccurrences, loaded.index.tokenOccurrences)
+
+	for prop, index := range db.index.indexes {
+		assert.Equal(t, index.Len(), loaded.index.indexes[prop].Len())
+	}
+
+	params := SearchParams{
+		Query:  "tom brown",
+		Offset: 0,
+		Limit:  10,
+	}
+	expected, _ := db.Search(&params)
+	actual, _ := loaded.Search(&params)
+
+	assert.ElementsMatch(t, expected.Hits, actual.Hits)
+}
+
+func TestLoadOtherAnalysis(t *testing.T) {
+	db, _ := New[User](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+	})
+	for _, doc := range testData {
+		_, _ = db.Insert(&InsertParams[User]{Document: doc})
+	}
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, db.Save(&buf))
+
+	// the index is rebuilt with the stemming enabled
+	config := &Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{EnableStemming: true},
+	}
+	loaded, err := Load[User](&buf, config)
+	assert.NoError(t, err)
+
+	expected, _ := New[User](config)
+	for id, doc := range db.documents {
+		expected.insert(id, doc, db.languages[id])
+	}
+	assert.Equal(t, db.documents, loaded.documents)
+	assert.Equal(t, db.languages, loaded.languages)
+	assert.Equal(t, expected.index.tokenOccurrences, loaded.index.tokenOccurrences)
+	assert.NotEqual(t, db.index.tokenOccurrences, loaded.index.tokenOccurrences)
+}
+
+func TestLoadInvalidSnapshot(t *testing.T) {
+	cases := []TestCase[[]byte, error]{
+		{
+			given:    []byte("MSDB"),
+			expected: &InvalidSnapshotError{Reason: "unexpected EOF"},
+		},
+		{
+			given:    []byte("JSON\x01\x00\x00\x00"),
+			expected: &InvalidSnapshotError{Reason: "unknown file format"},
+		},
+		{
+			given:    []byte("MSDB\xff\x00\x00\x00"),
+			expected: &SnapshotVersionError{Version: 255},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			_, err := Load[User](bytes.NewReader(c.given), &Config{
+				DefaultLanguage: tokenizer.ENGLISH,
+				TokenizerConfig: &tokenizer.Config{},
+			})
+			assert.Equal(t, c.expected, err)
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+	logConfig := &wal.Config{SyncPolicy: wal.SyncAlways}
+
+	l, _ := wal.Open(path, logConfig)
+	db, _ := New[User](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+		Log:             l,
+	})
+	db.InsertBatch(&InsertBatchParams[User]{
+		Documents: testData,
+		BatchSize: 3,
+		Language:  tokenizer.ENGLISH,
+	})
+
+	ids := make([]string, 0, len(db.documents))
+	for id := range db.documents {
+		ids = append(ids, id)
+	}
+	_, _ = db.Update(&UpdateParams[User]{Id: ids[0], Document: testData[1]})
+	_ = db.Delete(&DeleteParams[User]{Id: ids[1]})
+	assert.NoError(t, l.Close())
+
+	l, _ = wal.Open(path, logConfig)
+	replayed, _ := New[User](&Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+		Log:             l,
+	})
+	assert.NoError(t, replayed.Replay())
+
+	assert.Equal(t, db.documents, replayed.documents)
+	assert.Equal(t, db.index.fieldLengths, replayed.index.fieldLengths)
+	assert.Equal(t, db.index.tokenOccurrences, replayed.index.tokenOccurrences)
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, replayed.Checkpoint(&buf, func() error { return nil }))
+	assert.NoError(t, l.Close())
+
+	// the checkpoint truncates the log, so the snapshot alone has to restore the state
+	l, _ = wal.Open(path, logConfig)
+	restored, err := Load[User](&buf, &Config{
+		DefaultLanguage: tokenizer.ENGLISH,
+		TokenizerConfig: &tokenizer.Config{},
+		Log:             l,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, restored.Replay())
+	assert.Equal(t, db.documents, restored.documents)
+	assert.NoError(t, l.Close())
+}
+
+func TestFlattenSchema(t *testing.T) {
+	cases := []TestCase[any, map[string]any]{
+		{
+			given: User{
+				Name:   "micpst",
+				Email:  "micpst@email.com",
+				Joined: "2023-02-10T15:04:05Z07:00",
+			},
+			expected: map[string]any{
+				"name":  "micpst",
+				"email": "micpst@email.com",
+			},
+		},
+		{
+			given: Document{
+				Title:    "The Silicon Brain",
+				Abstract: "The human brain is often described as complex and while this is certainly true in many ways, its computational substrate is quite easy to understand.",
+				Url:      "https://micpst.com/posts/silicon-brain",
+				Author: User{
+					Name:   "micpst",
+					Email:  "micpst@email.com",
+					Joined: "2023-02-10T15:04:05Z07:00",
+				},
+			},
+			expected: map[string]any{
+				"title":        "The Silicon Brain",
+				"abstract":     "The human brain is often described as complex and while this is certainly true in many ways, its computational substrate is quite easy to understand.",
+				"author.name":  "micpst",
+				"author.email": "micpst@email.com",
+			},
+		},
+		{
+			given: Product{
+				Name:      "Red chair",
+				Category:  "furniture",
+				Available: true,
+				Price:     49.99,
+				Stock:     10,
+				Year:      2019,
+			},
+			expected: map[string]any{
+				"name":      "Red chair",
+				"category":  "furniture",
+				"available": true,
+				"price":     49.99,
+				"stock":     uint64(10),
+				"year":      int64(2019),
+			},
+		},
+		{
+			given: Article{
+				Title:      "Silicon brain",
+				Paragraphs: []string{"Neurons fire", "Chips compute"},
+				Ratings:    []int{3, 5},
+				Authors:    []User{{Name: "Tom"}, {Name: "Julia", Email: "julia@email.com"}},
+				Published:  time.Date(2023, 2, 10, 15, 4, 5, 0, time.UTC),
+			},
+			expected: map[string]any{
+				"title":         "Silicon brain",
+				"paragraphs":    []any{"Neurons fire", "Chips compute"},
+				"ratings":       []any{int64(3), int64(5)},
+				"authors.name":  []any{"Tom", "Julia"},
+				"authors.email": []any{"", "julia@email.com"},
+				"published":     int64(1676041445),
 			},
 		},
 	}
@@ -259,7 +2473,7 @@ func TestFlattenSchema(t *testing.T) {
 }
 
 func BenchmarkInsert(b *testing.B) {
-	db := New[User](&Config{
+	db, _ := New[User](&Config{
 		DefaultLanguage: tokenizer.ENGLISH,
 		TokenizerConfig: &tokenizer.Config{},
 	})
@@ -275,7 +2489,7 @@ func BenchmarkInsert(b *testing.B) {
 }
 
 func BenchmarkInsertBatch(b *testing.B) {
-	db := New[User](&Config{
+	db, _ := New[User](&Config{
 		DefaultLanguage: tokenizer.ENGLISH,
 		TokenizerConfig: &tokenizer.Config{},
 	})
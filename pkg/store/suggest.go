@@ -0,0 +1,254 @@
+package store
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/radix"
+	"github.com/micpst/minisearch/pkg/tokenizer"
+)
+
+const (
+	defaultSuggestLimit = 5
+	// surfaceSample bounds the number of documents read to find how a completed term is written
+	surfaceSample = 10
+)
+
+// SuggestParams complete a partial input. The last word is a prefix, matched with up to Tolerance edits,
+// while the earlier words restrict the completions to the documents containing all of them.
+type SuggestParams struct {
+	Query      string
+	Properties []string
+	Tolerance  int
+	Limit      int
+	Language   tokenizer.Language
+}
+
+// Suggestion is a completion of the input. Term is the indexed term completing the last word, Text the input
+// with the last word completed as it is written in the documents and Count the number of matching documents.
+type Suggestion struct {
+	Text     string
+	Term     string
+	Count    int
+	Distance int
+}
+
+type completion struct {
+	term     string
+	distance int
+	records  []map[string]recordInfo
+	upper    int
+}
+
+// count returns the number of allowed documents containing the completed term in any property.
+func (c *completion) count(allowed map[string]struct{}) int {
+	if len(c.records) == 1 && allowed == nil {
+		return len(c.records[0])
+	}
+
+	ids := make(map[string]struct{})
+	for _, records := range c.records {
+		for id := range records {
+			if _, ok := allowed[id]; ok || allowed == nil {
+				ids[id] = struct{}{}
+			}
+		}
+	}
+	return len(ids)
+}
+
+func (db *MemDB[S]) Suggest(params *SuggestParams) ([]Suggestion, error) {
+	properties := params.Properties
+	if len(params.Properties) == 0 {
+		properties = db.index.searchableProperties
+	}
+	for _, prop := range properties {
+		if _, ok := db.index.indexes[prop]; !ok {
+			return nil, &WrongSearchPropertyType{Property: prop}
+		}
+	}
+
+	language := params.Language
+	if params.Language == "" {
+		language = db.defaultLanguage
+
+	} else if !tokenizer.IsSupportedLanguage(language) {
+		return nil, &tokenizer.LanguageNotSupportedError{Language: language}
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+
+	// the input is split without stemming nor stop words, so that the last word keeps the typed prefix
+	splitter, err := tokenizer.NewAnalyzer(language, &tokenizer.Config{})
+	if err != nil {
+		return nil, err
+	}
+	words := splitter.TokenizeWithOffsets(&tokenizer.AnalyzeParams{
+		Text:            params.Query,
+		AllowDuplicates: true,
+	})
+	if len(words) == 0 {
+		return []Suggestion{}, nil
+	}
+
+	last := words[len(words)-1]
+	head := params.Query[:last.Start]
+
+	analyzers := make(map[string]*tokenizer.Analyzer, len(properties))
+	for _, prop := range properties {
+		analyzers[prop] = db.analyzer(prop, language)
+	}
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	var allowed map[string]struct{}
+	for _, word := range words[:len(words)-1] {
+		ids, analyzed := make(map[string]struct{}), false
+		for _, prop := range properties {
+			terms := analyzers[prop].Tokenize(&tokenizer.AnalyzeParams{
+				Text:            params.Query[word.Start:word.End],
+				AllowDuplicates: false,
+			})
+			for _, term := range terms {
+				analyzed = true
+				for _, r := range db.index.indexes[prop].Find(&radix.FindParams{Term: term, Exact: true}) {
+					for id := range r.Data {
+						if _, ok := allowed[id]; ok || allowed == nil {
+							ids[id] = struct{}{}
+						}
+					}
+				}
+			}
+		}
+		// the stop words do not restrict the completions
+		if analyzed {
+			allowed = ids
+		}
+	}
+
+	completions := make(map[string]*completion)
+	for _, prop := range properties {
+		// a complete last word is indexed by its stem, which may not start with the typed word
+		prefixes := []string{last.Value}
+		stems := analyzers[prop].Tokenize(&tokenizer.AnalyzeParams{
+			Text:            params.Query[last.Start:last.End],
+			AllowDuplicates: false,
+		})
+		if len(stems) == 1 && stems[0] != last.Value {
+			prefixes = append(prefixes, stems[0])
+		}
+
+		for _, prefix := range prefixes {
+			results := db.index.indexes[prop].FindPrefix(&radix.PrefixParams{
+				Prefix:    prefix,
+				Tolerance: params.Tolerance,
+			})
+			for _, r := range results {
+				c, ok := completions[r.Term]
+				if !ok {
+					c = &completion{term: r.Term, distance: r.Distance}
+					completions[r.Term] = c
+				}
+				c.distance = min(c.distance, r.Distance)
+				c.records = append(c.records, r.Data)
+				c.upper += len(r.Data)
+			}
+		}
+	}
+
+	candidates := make([]*completion, 0, len(completions))
+	for _, c := range completions {
+		if allowed != nil {
+			c.upper = min(c.upper, len(allowed))
+		}
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].upper > candidates[j].upper
+	})
+
+	// the counts are only computed until no remaining completion can enter the top suggestions
+	suggestions := make([]Suggestion, 0, limit+1)
+	for _, c := range candidates {
+		if len(suggestions) == limit && c.upper < suggestions[limit-1].Count {
+			break
+		}
+
+		count := c.count(allowed)
+		if count == 0 {
+			continue
+		}
+
+		suggestions = append(suggestions, Suggestion{Term: c.term, Count: count, Distance: c.distance})
+		sort.Slice(suggestions, func(i, j int) bool {
+			if suggestions[i].Count != suggestions[j].Count {
+				return suggestions[i].Count > suggestions[j].Count
+			}
+			if suggestions[i].Distance != suggestions[j].Distance {
+				return suggestions[i].Distance < suggestions[j].Distance
+			}
+			return suggestions[i].Term < suggestions[j].Term
+		})
+		if len(suggestions) > limit {
+			suggestions = suggestions[:limit]
+		}
+	}
+
+	for i := range suggestions {
+		surface := db.surface(suggestions[i].Term, completions[suggestions[i].Term].records, allowed, properties)
+		suggestions[i].Text = head + surface
+	}
+
+	return suggestions, nil
+}
+
+// surface returns the most frequent way an indexed term is written in a sample of the documents,
+// each analyzed in the language it is indexed with.
+func (db *MemDB[S]) surface(term string, records []map[string]recordInfo, allowed map[string]struct{}, properties []string) string {
+	counts := make(map[string]int)
+	sampled := make(map[string]struct{}, surfaceSample)
+
+	for _, data := range records {
+		for id := range data {
+			if len(sampled) == surfaceSample {
+				break
+			}
+			if _, ok := allowed[id]; !ok && allowed != nil {
+				continue
+			}
+			if _, ok := sampled[id]; ok {
+				continue
+			}
+			sampled[id] = struct{}{}
+
+			fields := flattenSchema(db.documents[id])
+			for _, prop := range properties {
+				for _, value := range values(fields[prop]) {
+					text := value.(string)
+					tokens := db.analyzer(prop, db.languages[id]).TokenizeWithOffsets(&tokenizer.AnalyzeParams{
+						Text:            text,
+						AllowDuplicates: true,
+					})
+					for _, token := range tokens {
+						if token.Value == term {
+							counts[strings.ToLower(text[token.Start:token.End])]++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	surface, best := term, 0
+	for word, count := range counts {
+		if count > best || count == best && (len(word) < len(surface) || len(word) == len(surface) && word < surface) {
+			surface, best = word, count
+		}
+	}
+
+	return surface
+}
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/micpst/minisearch/pkg/hnsw"
+	"github.com/micpst/minisearch/pkg/lib"
+)
+
+const (
+	COSINE VectorMetric = "cosine"
+	DOT    VectorMetric = "dot"
+	L2     VectorMetric = "l2"
+)
+
+const defaultVectorK = 10
+
+type VectorMetric string
+
+// VectorParams search the K documents with the vectors of the property most similar to the given one.
+// Ef overrides the size of the candidate list of the HNSW index, if the store has one.
+type VectorParams struct {
+	Property string
+	Value    []float32
+	K        int
+	Ef       int
+}
+
+type vectorMatch[K recordId] struct {
+	id    K
+	score float64
+}
+
+// vectorIndex finds the nearest neighbours of a vector in its HNSW graph, or with an exact scan
+// over all the stored vectors if it has none.
+type vectorIndex[K recordId] struct {
+	dims    int
+	metric  VectorMetric
+	vectors map[K][]float32
+	graph   *hnsw.Graph[K]
+}
+
+func newVectorIndex[K recordId](dims int, metric VectorMetric, c *hnsw.Config) *vectorIndex[K] {
+	v := &vectorIndex[K]{
+		dims:    dims,
+		metric:  metric,
+		vectors: make(map[K][]float32),
+	}
+	if c != nil {
+		config := *c
+		config.Distance = v.distance
+		v.graph = hnsw.New[K](config)
+	}
+	return v
+}
+
+func (v *vectorIndex[K]) insert(id K, vector []float32) {
+	v.vectors[id] = vector
+	// a graph restored from a snapshot already holds the vectors of the loaded documents
+	if v.graph != nil && !v.graph.Contains(id) {
+		v.graph.Insert(&hnsw.InsertParams[K]{Id: id, Vector: vector})
+	}
+}
+
+func (v *vectorIndex[K]) delete(id K) {
+	delete(v.vectors, id)
+	if v.graph != nil {
+		v.graph.Delete(id)
+	}
+}
+
+// similarity is higher for closer vectors whatever the metric, L2 distances are mapped to (0, 1].
+func (v *vectorIndex[K]) similarity(a []float32, b []float32) float64 {
+	switch v.metric {
+	case DOT:
+		return lib.DotProduct(a, b)
+	case L2:
+		return 1 / (1 + lib.EuclideanDistance(a, b))
+	default:
+		return lib.CosineSimilarity(a, b)
+	}
+}
+
+// distance orders the vectors of the graph the same way as their similarity.
+func (v *vectorIndex[K]) distance(a []float32, b []float32) float64 {
+	return -v.similarity(a, b)
+}
+
+// search returns the k most similar vectors, restricted to the allowed ids unless they are nil.
+func (v *vectorIndex[K]) search(query []float32, k int, ef int, allowed map[K]struct{}) []vectorMatch[K] {
+	if v.graph == nil {
+		return v.scan(query, k, allowed)
+	}
+
+	var filter func(K) bool
+	candidates := len(v.vectors)
+	if allowed != nil {
+		filter = func(id K) bool {
+			_, ok := allowed[id]
+			return ok
+		}
+		candidates = min(candidates, len(allowed))
+	}
+
+	results := v.graph.Search(&hnsw.SearchParams[K]{Vector: query, K: k, Ef: ef, Filter: filter})
+
+	// a restrictive filter can cut the graph search short, the exact scan finds all the allowed vectors
+	if len(results) < k && len(results) < candidates {
+		return v.scan(query, k, allowed)
+	}
+
+	matches := make([]vectorMatch[K], len(results))
+	for i, result := range results {
+		matches[i] = vectorMatch[K]{id: result.Id, score: -result.Distance}
+	}
+
+	return matches
+}
+
+func (v *vectorIndex[K]) scan(query []float32, k int, allowed map[K]struct{}) []vectorMatch[K] {
+	matches := make([]vectorMatch[K], 0, len(v.vectors))
+	for id, vector := range v.vectors {
+		if allowed != nil {
+			if _, ok := allowed[id]; !ok {
+				continue
+			}
+		}
+		matches = append(matches, vectorMatch[K]{id: id, score: v.similarity(query, vector)})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	if len(matches) > k {
+		matches = matches[:k]
+	}
+
+	return matches
+}
+
+func (idx *index[K, S]) validateVector(params *VectorParams) error {
+	vectors, ok := idx.vectors[params.Property]
+	if !ok {
+		return &WrongVectorPropertyType{Property: params.Property}
+	}
+	if len(params.Value) != vectors.dims {
+		return &VectorDimensionError{Property: params.Property, Expected: vectors.dims, Actual: len(params.Value)}
+	}
+	return nil
+}
+
+func (db *MemDB[S]) evaluateVector(params *VectorParams, filtered map[string]struct{}, explain bool) map[string]match {
+	k := params.K
+	if k <= 0 {
+		k = defaultVectorK
+	}
+
+	vectors := db.index.vectors[params.Property]
+	matches := vectors.search(params.Value, k, params.Ef, filtered)
+
+	idMatches := make(map[string]match, len(matches))
+	for _, m := range matches {
+		idMatch := match{score: m.score}
+		if explain {
+			idMatch.explanation = &Explanation{
+				Score:       m.score,
+				Description: fmt.Sprintf("%s similarity", vectors.metric),
+			}
+		}
+		idMatches[m.id] = idMatch
+	}
+
+	return idMatches
+}
@@ -0,0 +1,232 @@
+package tokenizer
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+// Lowercase maps every rune to lower case.
+var Lowercase CharFilter = CharFilterFunc(unicode.ToLower)
+
+// RemoveDiacritics strips the combining marks of a token, e.g. "élan" becomes "elan".
+var RemoveDiacritics TokenFilter = TokenFilterFunc(func(token string) string {
+	if normToken, _, err := transform.String(normalizer, token); err == nil {
+		return normToken
+	}
+	return token
+})
+
+// defaultTokenizer splits the text on every rune that is neither a letter nor a digit.
+var defaultTokenizer Tokenizer = &RegexpTokenizer{Separator: regexp.MustCompile(`[^\pL\pN]+`)}
+
+// CharFilter maps every rune of the text before it is split, a negative rune is dropped like in strings.Map.
+type CharFilter interface {
+	Filter(r rune) rune
+}
+
+// Tokenizer splits the text into the byte ranges of its tokens.
+type Tokenizer interface {
+	Split(text string) [][2]int
+}
+
+// TokenFilter transforms a token, an empty token is removed.
+type TokenFilter interface {
+	Filter(token string) string
+}
+
+type CharFilterFunc func(r rune) rune
+
+func (f CharFilterFunc) Filter(r rune) rune {
+	return f(r)
+}
+
+type TokenFilterFunc func(token string) string
+
+func (f TokenFilterFunc) Filter(token string) string {
+	return f(token)
+}
+
+// RegexpTokenizer splits the text on the matches of Separator.
+type RegexpTokenizer struct {
+	Separator *regexp.Regexp
+}
+
+func (t *RegexpTokenizer) Split(text string) [][2]int {
+	spans := make([][2]int, 0)
+	start := 0
+	for _, separator := range t.Separator.FindAllStringIndex(text, -1) {
+		if separator[0] > start {
+			spans = append(spans, [2]int{start, separator[0]})
+		}
+		start = separator[1]
+	}
+	if start < len(text) {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return spans
+}
+
+// KeywordTokenizer keeps the whole text as a single token, without the surrounding spaces.
+type KeywordTokenizer struct{}
+
+func (t *KeywordTokenizer) Split(text string) [][2]int {
+	start := len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace))
+	end := len(strings.TrimRightFunc(text, unicode.IsSpace))
+	if start >= end {
+		return [][2]int{}
+	}
+	return [][2]int{{start, end}}
+}
+
+// Filter drops the stop words.
+func (s StopWords) Filter(token string) string {
+	if _, ok := s[token]; ok {
+		return ""
+	}
+	return token
+}
+
+// Filter replaces a token with its stem.
+func (s Stem) Filter(token string) string {
+	return s(token, false)
+}
+
+// Analyzer turns a text into tokens. The char filters map the runes of the text in order, the tokenizer
+// splits the result, by default on every rune that is neither a letter nor a digit, and the token filters
+// transform every token in order.
+type Analyzer struct {
+	CharFilters  []CharFilter
+	Tokenizer    Tokenizer
+	TokenFilters []TokenFilter
+}
+
+type AnalyzeParams struct {
+	Text            string
+	AllowDuplicates bool
+}
+
+// NewAnalyzer returns the built-in analyzer of a language: the text is lower-cased and split by the rules
+// of the language, then the stop words are removed and the tokens stemmed if enabled in the config,
+// and finally the diacritics are stripped.
+func NewAnalyzer(language Language, config *Config) (*Analyzer, error) {
+	languagesMutex.RLock()
+	defer languagesMutex.RUnlock()
+
+	splitRule, ok := splitRules[language]
+	if !ok {
+		return nil, &LanguageNotSupportedError{language}
+	}
+
+	filters := make([]TokenFilter, 0, 3)
+	if words, ok := stopWords[language]; config.EnableStopWords && ok {
+		filters = append(filters, words)
+	}
+	if stem, ok := stems[language]; config.EnableStemming && ok {
+		filters = append(filters, stem)
+	}
+	filters = append(filters, RemoveDiacritics)
+
+	return &Analyzer{
+		CharFilters:  []CharFilter{Lowercase},
+		Tokenizer:    &RegexpTokenizer{Separator: splitRule},
+		TokenFilters: filters,
+	}, nil
+}
+
+func (a *Analyzer) Tokenize(params *AnalyzeParams) []string {
+	text, _ := a.filterChars(params.Text, false)
+
+	tokens := make([]string, 0)
+	uniqueTokens := make(map[string]struct{})
+
+	for _, span := range a.split(text) {
+		if token := a.filterToken(text[span[0]:span[1]]); token != "" {
+			if _, ok := uniqueTokens[token]; !ok || params.AllowDuplicates {
+				uniqueTokens[token] = struct{}{}
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// TokenizeWithOffsets returns the same tokens as Tokenize, along with their offsets in params.Text.
+func (a *Analyzer) TokenizeWithOffsets(params *AnalyzeParams) []Token {
+	text, offsets := a.filterChars(params.Text, true)
+
+	tokens := make([]Token, 0)
+	uniqueTokens := make(map[string]struct{})
+
+	for _, span := range a.split(text) {
+		if token := a.filterToken(text[span[0]:span[1]]); token != "" {
+			if _, ok := uniqueTokens[token]; !ok || params.AllowDuplicates {
+				uniqueTokens[token] = struct{}{}
+				tokens = append(tokens, Token{Value: token, Start: offsets[span[0]], End: offsets[span[1]]})
+			}
+		}
+	}
+
+	return tokens
+}
+
+// filterChars applies the char filters to the text. The filters can change the size of a rune,
+// so with offsets every byte of the result points back to its source in the text.
+func (a *Analyzer) filterChars(text string, withOffsets bool) (string, []int) {
+	if len(a.CharFilters) == 0 && !withOffsets {
+		return text, nil
+	}
+
+	filtered := strings.Builder{}
+	filtered.Grow(len(text))
+	var offsets []int
+	if withOffsets {
+		offsets = make([]int, 0, len(text)+1)
+	}
+
+	for i, r := range text {
+		for _, filter := range a.CharFilters {
+			if r = filter.Filter(r); r < 0 {
+				break
+			}
+		}
+		if r < 0 {
+			continue
+		}
+		if !utf8.ValidRune(r) {
+			r = utf8.RuneError
+		}
+
+		if withOffsets {
+			for n := utf8.RuneLen(r); n > 0; n-- {
+				offsets = append(offsets, i)
+			}
+		}
+		filtered.WriteRune(r)
+	}
+	if withOffsets {
+		offsets = append(offsets, len(text))
+	}
+
+	return filtered.String(), offsets
+}
+
+func (a *Analyzer) split(text string) [][2]int {
+	if a.Tokenizer == nil {
+		return defaultTokenizer.Split(text)
+	}
+	return a.Tokenizer.Split(text)
+}
+
+func (a *Analyzer) filterToken(token string) string {
+	for _, filter := range a.TokenFilters {
+		if token = filter.Filter(token); token == "" {
+			break
+		}
+	}
+	return token
+}
@@ -0,0 +1,174 @@
+package tokenizer
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/tokenizer/samples"
+)
+
+// AUTO requests the detection of the language of a text.
+const AUTO Language = "auto"
+
+const (
+	// maxNgram is the length of the longest character n-grams of the language profiles.
+	maxNgram = 3
+	// sampleWeight is the number of times a sample text is counted, it is running text unlike the stop words.
+	sampleWeight = 4
+)
+
+var wordRule = regexp.MustCompile(`\pL+`)
+
+var languageSamples = map[Language]string{
+	DANISH:     samples.Danish,
+	DUTCH:      samples.Dutch,
+	ENGLISH:    samples.English,
+	FINNISH:    samples.Finnish,
+	FRENCH:     samples.French,
+	GERMAN:     samples.German,
+	HUNGARIAN:  samples.Hungarian,
+	ITALIAN:    samples.Italian,
+	NORWEGIAN:  samples.Norwegian,
+	PORTUGUESE: samples.Portuguese,
+	RUSSIAN:    samples.Russian,
+	SPANISH:    samples.Spanish,
+	SWEDISH:    samples.Swedish,
+}
+
+// profiles are built on the first detection and again after a language is registered.
+var profiles map[Language]*profile
+
+// Detection is the most probable language of a text, with its probability among the supported languages.
+type Detection struct {
+	Language   Language
+	Confidence float64
+}
+
+// profile holds the log-probabilities of the character n-grams of a language.
+type profile struct {
+	ngrams map[string]float64
+	unseen float64
+}
+
+// DetectLanguage identifies the language of a text with a naive Bayes classifier over the character n-grams
+// and the words of the text, trained on a sample text and the stop words of every supported language.
+// The detection is empty if the text has no letters.
+func DetectLanguage(text string) Detection {
+	profiles := languageProfiles()
+
+	ngrams := make(map[string]int)
+	countNgrams(text, ngrams)
+	if len(ngrams) == 0 {
+		return Detection{}
+	}
+
+	scores := make(map[Language]float64, len(profiles))
+	best := Detection{}
+	for language, p := range profiles {
+		score := 0.0
+		for ngram, count := range ngrams {
+			logProbability, ok := p.ngrams[ngram]
+			if !ok {
+				logProbability = p.unseen
+			}
+			score += float64(count) * logProbability
+		}
+		scores[language] = score
+
+		if best.Language == "" || score > scores[best.Language] || score == scores[best.Language] && language < best.Language {
+			best.Language = language
+		}
+	}
+
+	// the confidence is the posterior probability of the best language, with uniform priors. Every letter is part
+	// of several overlapping n-grams, so the scores are tempered to not count the same evidence again and again.
+	total := 0.0
+	for _, score := range scores {
+		total += math.Exp((score - scores[best.Language]) / (maxNgram + 1))
+	}
+	best.Confidence = 1 / total
+
+	return best
+}
+
+func languageProfiles() map[Language]*profile {
+	languagesMutex.RLock()
+	p := profiles
+	languagesMutex.RUnlock()
+	if p != nil {
+		return p
+	}
+
+	languagesMutex.Lock()
+	defer languagesMutex.Unlock()
+	if profiles == nil {
+		profiles = buildProfiles()
+	}
+	return profiles
+}
+
+// buildProfiles trains the profiles of all the supported languages. The n-grams missing from a profile get
+// the same small probability in every language, so that the languages with less training text are not favoured.
+func buildProfiles() map[Language]*profile {
+	counts := make(map[Language]map[string]int, len(splitRules))
+	totals := make(map[Language]int, len(splitRules))
+	vocabulary := make(map[string]struct{})
+
+	for language := range splitRules {
+		counts[language] = make(map[string]int)
+		for i := 0; i < sampleWeight; i++ {
+			countNgrams(languageSamples[language], counts[language])
+		}
+		for word := range stopWords[language] {
+			countNgrams(word, counts[language])
+		}
+		// a language without any text to learn from is never detected
+		if len(counts[language]) == 0 {
+			delete(counts, language)
+			continue
+		}
+		for ngram, count := range counts[language] {
+			totals[language] += count
+			vocabulary[ngram] = struct{}{}
+		}
+	}
+
+	maxTotal := 0
+	for _, total := range totals {
+		maxTotal = max(maxTotal, total)
+	}
+	unseen := math.Log(1 / float64(maxTotal+len(vocabulary)))
+
+	result := make(map[Language]*profile, len(counts))
+	for language, ngrams := range counts {
+		p := &profile{ngrams: make(map[string]float64, len(ngrams)), unseen: unseen}
+		// add-one smoothing over the n-grams of all the languages
+		denominator := float64(totals[language] + len(vocabulary))
+		for ngram, count := range ngrams {
+			p.ngrams[ngram] = math.Log(float64(count+1) / denominator)
+		}
+		result[language] = p
+	}
+
+	return result
+}
+
+// countNgrams counts the n-grams of the lower-cased words of the text, padded with spaces to mark their boundaries,
+// and the longer words themselves, which tell apart closely related languages sharing most of their n-grams.
+func countNgrams(text string, counts map[string]int) {
+	for _, word := range wordRule.FindAllString(strings.ToLower(text), -1) {
+		runes := []rune(" " + word + " ")
+		for n := 1; n <= maxNgram; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				if n == 1 && runes[i] == ' ' {
+					continue
+				}
+				counts[string(runes[i:i+n])]++
+			}
+		}
+		if len(runes) > maxNgram {
+			counts[string(runes)]++
+		}
+	}
+}
@@ -9,3 +9,12 @@ type LanguageNotSupportedError struct {
 func (e *LanguageNotSupportedError) Error() string {
 	return fmt.Sprintf("Language '%s' is not supported", e.Language)
 }
+
+type InvalidLanguageError struct {
+	Language Language
+	Reason   string
+}
+
+func (e *InvalidLanguageError) Error() string {
+	return fmt.Sprintf("Language '%s' is invalid: %s", e.Language, e.Reason)
+}
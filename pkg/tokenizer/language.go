@@ -0,0 +1,114 @@
+package tokenizer
+
+import (
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// languagesMutex guards the split rules, stop words, stems, samples, detection profiles and revision of the languages.
+var languagesMutex sync.RWMutex
+
+// revision counts the registered languages.
+var revision uint64
+
+// LanguageParams define a language registered with RegisterLanguage. SplitRule matches the separators of
+// the words of the lower-cased text. The StopWords and the Stem are optional, and Sample is running text
+// of the language used, along with the stop words, to detect it. The built-in languages are trained on
+// several hundred words each, a much shorter sample makes the detection favour the other languages.
+type LanguageParams struct {
+	SplitRule *regexp.Regexp
+	StopWords StopWords
+	Stem      Stem
+	Sample    string
+}
+
+// RegisterLanguage adds a language, or replaces the definition of a supported one.
+func RegisterLanguage(language Language, params *LanguageParams) error {
+	if language == "" || language == AUTO {
+		return &InvalidLanguageError{Language: language, Reason: "reserved language name"}
+	}
+	if params.SplitRule == nil {
+		return &InvalidLanguageError{Language: language, Reason: "split rule is required"}
+	}
+
+	languagesMutex.Lock()
+	defer languagesMutex.Unlock()
+
+	splitRules[language] = params.SplitRule
+	delete(stopWords, language)
+	if params.StopWords != nil {
+		stopWords[language] = params.StopWords
+	}
+	delete(stems, language)
+	if params.Stem != nil {
+		stems[language] = params.Stem
+	}
+	delete(languageSamples, language)
+	if params.Sample != "" {
+		languageSamples[language] = params.Sample
+	}
+	// the profiles are rebuilt with the new language on the next detection
+	profiles = nil
+	revision++
+
+	return nil
+}
+
+// Languages returns the supported languages in order.
+func Languages() []Language {
+	languagesMutex.RLock()
+	defer languagesMutex.RUnlock()
+
+	languages := make([]Language, 0, len(splitRules))
+	for language := range splitRules {
+		languages = append(languages, language)
+	}
+	sort.Slice(languages, func(i, j int) bool { return languages[i] < languages[j] })
+	return languages
+}
+
+// Revision changes with every registered language, the analyzers built before may be outdated.
+func Revision() uint64 {
+	languagesMutex.RLock()
+	defer languagesMutex.RUnlock()
+
+	return revision
+}
+
+// Fingerprint identifies the rules of a supported language by hashing its split rule, its stop words and the stems
+// of its stop words and sample words. It changes when the language is registered again with other rules.
+func Fingerprint(language Language) (uint64, error) {
+	languagesMutex.RLock()
+	defer languagesMutex.RUnlock()
+
+	splitRule, ok := splitRules[language]
+	if !ok {
+		return 0, &LanguageNotSupportedError{Language: language}
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(splitRule.String()))
+
+	words := make([]string, 0, len(stopWords[language]))
+	for word := range stopWords[language] {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	for _, word := range words {
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(word))
+	}
+
+	if stem, ok := stems[language]; ok {
+		words = append(words, wordRule.FindAllString(strings.ToLower(languageSamples[language]), -1)...)
+		for _, word := range words {
+			_, _ = h.Write([]byte{1})
+			_, _ = h.Write([]byte(stem(word, true)))
+		}
+	}
+
+	return h.Sum64(), nil
+}
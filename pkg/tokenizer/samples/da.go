@@ -0,0 +1,48 @@
+package samples
+
+var Danish = `Alle mennesker er født frie og lige i værdighed og rettigheder. De er udstyret med fornuft og
+samvittighed, og de bør handle mod hverandre i en broderskabets ånd. Vejret var koldt i morges, så vi blev hjemme
+og læste bøger ved pejsen. Søgemaskiner bygger et indeks over de ord, der findes i hvert dokument, hvilket gør det
+muligt hurtigt at finde de mest relevante sider. Børn lærer sprog ved at lytte til menneskene omkring dem og ved
+selv at prøve at tale.
+Hver morgen går min nabo tur med sin hund langs åen, før hun tager på arbejde. Byens bibliotek åbner klokken ni
+og lukker sent om aftenen, og det er ofte fyldt med studerende, som forbereder sig til deres eksamener. Vi spiser
+som regel aftensmad sammen og taler om, hvad der er sket i løbet af dagen.
+Sidste sommer lejede vi et sommerhus ved en sø i Jylland. Der var ingen strøm, så vi lavede maden på et brændekomfur
+og hentede vand fra brønden bag huset. Om aftenen sad vi ude på terrassen og så solen gå ned, mens myggene summede
+omkring os. Børnene badede hver dag, også når det regnede, og de lærte at ro den gamle båd ud til den lille ø midt
+i søen. Jeg havde tænkt mig at skrive min bog færdig, men jeg nåede ikke længere end til andet kapitel.
+Regeringen fremlagde i går et nyt forslag om, hvordan kommunerne skal finansiere ældreplejen. Ifølge forslaget
+skal kommuner med mange ældre borgere have et større tilskud fra staten, mens de kommuner, der vokser hurtigt, får
+mindre. Oppositionen kritiserede forslaget og mente, at det ikke løser problemet med manglen på sygeplejersker.
+Sagen skal nu undersøges nærmere, og der ventes ikke nogen afgørelse før efter valget til efteråret.
+Min mormor blev født på en gård uden for Aalborg og voksede op med syv søskende. Hun fortalte tit om de lange
+vintre, hvor sneen lå en meter høj, og hvor de måtte skovle sig ud ad døren om morgenen. Hun lærte at strikke, da
+hun var fem år gammel, og holdt aldrig op, heller ikke da hun blev halvfems. Jeg har stadig flere af hendes
+trøjer og vanter, og jeg tænker på hende, hver gang jeg tager dem på.
+Det er svært at finde en lejlighed i København, især hvis man er ung og ikke har sparet noget op. Mange
+studerende bor til fremleje og må flytte flere gange om året. Priserne er steget voldsomt, og den, der vil købe
+en bolig, har ofte brug for hjælp fra sine forældre. Politikerne har lovet at bygge flere almene boliger, men det
+går langsomt.
+Virksomheden har udviklet en app, som hjælper brugerne med at holde øje med deres elforbrug. Appen viser, hvor
+meget strøm der bruges i husstanden i løbet af døgnet, og foreslår, hvornår det er billigst at vaske tøj eller
+oplade bilen. Ifølge virksomheden kan en almindelig familie spare flere tusinde kroner om året. Tjenesten er
+gratis det første halve år, derefter koster den fyrre kroner om måneden.
+Da jeg var lille, ville jeg være lokomotivfører. Jeg samlede på togbilletter og kunne remse alle stationerne
+mellem Esbjerg og Helsingør op udenad. Senere blev jeg i stedet interesseret i fugle, og nu arbejder jeg som
+biolog i et naturreservat ved kysten. Hvert forår tæller vi tranerne, som hviler sig på markerne, før de flyver
+videre mod nord, og i år var der flere end nogensinde før.
+Vi spiste frokost i haven i august og sang, indtil det blev mørkt. Naboerne kom over med hjemmebagt rugbrød og
+en stor ost, og nogen havde taget en guitar med. Det var en af de bedste aftener længe, og ingen havde lyst til
+at gå hjem. Næste år vil vi prøve at holde festen igen, men da i haven hos mine forældre, fordi den er større.
+Skolen vil i efteråret indføre en ny ordning for lektiehjælp. Eleverne kan blive efter timerne og få hjælp af
+lærerne og de ældre elever i matematik, dansk og engelsk. Skolelederen håber, at flere vil bestå, og at færre
+skal gå et år om. Forældrene er positive, men nogle spørger sig selv, hvordan lærerne skal nå det hele.
+Om lørdagen er parken fuld af mennesker. Børnene leger i sandkassen eller løber efter duerne, mens forældrene
+sidder på bænkene og drikker kaffe. En gammel mand fodrer ænderne ved søen, og nogle teenagere spiller fodbold på
+plænen. Hunden vil altid med, men den må ikke bade i søen, så den gør ad fuglene i stedet. Jeg kan godt lide at gå
+derhen tidligt, når der stadig er stille, og solen lige er stået op. Nogle gange tager jeg en bog med, men som
+regel sidder jeg bare og kigger på folk, der går forbi. Det føles, som om byen langsomt vågner omkring mig. Hvorfor
+skulle man blive inde, når det er så dejligt udenfor? Hvad koster en kop kaffe i kiosken nu om dage? Ikke ret
+meget, men den smager bedre end derhjemme. Bagefter køber jeg ind i butikken på hjørnet og cykler hjem gennem
+skoven.`
@@ -0,0 +1,48 @@
+package samples
+
+var German = `Alle Menschen sind frei und gleich an Würde und Rechten geboren. Sie sind mit Vernunft und Gewissen
+begabt und sollen einander im Geist der Brüderlichkeit begegnen. Heute Morgen war das Wetter kalt, deshalb sind
+wir zu Hause geblieben und haben am Feuer Bücher gelesen. Suchmaschinen erstellen einen Index der Wörter, die in
+jedem Dokument vorkommen, damit die wichtigsten Seiten schnell gefunden werden können. Kinder lernen Sprachen,
+indem sie den Menschen um sich herum zuhören und selbst versuchen zu sprechen.
+Jeden Morgen geht meine Nachbarin mit ihrem Hund am Fluss spazieren, bevor sie zur Arbeit fährt. Die Stadtbibliothek
+öffnet um neun Uhr und schließt spät am Abend, und sie ist oft voller Studenten, die sich auf ihre Prüfungen
+vorbereiten. Wir essen meistens gemeinsam zu Abend und sprechen darüber, was während des Tages passiert ist.
+Letzten Sommer haben wir eine Hütte an einem See in den Bergen gemietet. Es gab keinen Strom, also haben wir auf
+einem Holzofen gekocht und das Wasser aus der Quelle hinter dem Haus geholt. Abends saßen wir auf der Veranda und
+schauten uns den Sonnenuntergang an, während die Mücken um uns herum summten. Die Kinder sind jeden Tag schwimmen
+gegangen, auch wenn es geregnet hat, und sie haben gelernt, das alte Boot bis zu der kleinen Insel in der Mitte
+des Sees zu rudern. Ich wollte eigentlich mein Buch fertig schreiben, bin aber nicht über das zweite Kapitel
+hinausgekommen.
+Die Bundesregierung hat gestern einen neuen Vorschlag vorgelegt, wie die Gemeinden die Pflege älterer Menschen
+finanzieren sollen. Nach dem Vorschlag sollen Gemeinden mit vielen älteren Einwohnern einen größeren Zuschuss vom
+Staat bekommen, während schnell wachsende Gemeinden weniger erhalten. Die Opposition kritisierte den Plan und
+sagte, dass er das Problem des Mangels an Pflegekräften nicht löse. Die Frage soll nun weiter geprüft werden, und
+eine Entscheidung wird erst nach der Wahl im Herbst erwartet.
+Meine Großmutter wurde auf einem Bauernhof in der Nähe einer kleinen Stadt im Norden geboren und ist mit sieben
+Geschwistern aufgewachsen. Sie erzählte oft von den langen Wintern, wenn der Schnee einen Meter hoch lag und sie
+sich morgens den Weg aus der Tür freischaufeln mussten. Sie hat mit fünf Jahren stricken gelernt und nie damit
+aufgehört, nicht einmal, als sie neunzig wurde. Ich habe noch immer mehrere ihrer Pullover und Handschuhe, und
+ich denke jedes Mal an sie, wenn ich sie anziehe.
+Es ist schwierig, in München eine Wohnung zu finden, besonders wenn man jung ist und kein Geld gespart hat. Viele
+Studenten wohnen zur Untermiete und müssen mehrmals im Jahr umziehen. Die Mieten sind stark gestiegen, und wer
+eine Wohnung kaufen möchte, braucht oft die Hilfe seiner Eltern. Die Politiker haben versprochen, mehr bezahlbaren
+Wohnraum zu schaffen, aber es geht nur langsam voran.
+Das Unternehmen hat eine App entwickelt, mit der die Nutzer ihren Stromverbrauch im Blick behalten können. Die
+App zeigt, wie viel Strom der Haushalt zu den verschiedenen Tageszeiten verbraucht, und schlägt vor, wann es am
+günstigsten ist, die Wäsche zu waschen oder das Auto zu laden. Nach Angaben des Unternehmens kann eine normale
+Familie dadurch mehrere hundert Euro im Jahr sparen. Der Dienst ist im ersten halben Jahr kostenlos, danach kostet
+er vier Euro im Monat.
+Als ich klein war, wollte ich Lokführer werden. Ich habe alte Fahrkarten gesammelt und konnte alle Bahnhöfe
+zwischen Hamburg und München auswendig aufsagen. Später habe ich mich stattdessen für Vögel interessiert, und
+heute arbeite ich als Biologe in einem Naturschutzgebiet an der Küste. Jedes Frühjahr zählen wir die Kraniche,
+die sich auf den Feldern ausruhen, bevor sie weiter nach Norden fliegen, und in diesem Jahr waren es mehr als je
+zuvor.
+Im August haben wir im Garten gegrillt und Lieder gesungen, bis es dunkel wurde. Die Nachbarn kamen mit
+selbstgebackenem Brot und einem großen Stück Käse vorbei, und jemand hatte eine Gitarre mitgebracht. Es war einer
+der schönsten Abende seit langem, und niemand wollte nach Hause gehen. Nächstes Jahr wollen wir das Fest wieder
+feiern, dann aber im Garten meiner Eltern, weil er größer ist.
+Die Schule wird im Herbst ein neues Angebot für die Hausaufgabenhilfe einführen. Die Schüler können nach dem
+Unterricht bleiben und sich von Lehrern und älteren Schülern in Mathematik, Deutsch und Englisch helfen lassen.
+Die Schulleiterin hofft, dass mehr Schüler ihren Abschluss schaffen und weniger eine Klasse wiederholen müssen.
+Die Eltern sind begeistert, aber manche fragen sich, wie die Lehrer das zeitlich schaffen sollen.`
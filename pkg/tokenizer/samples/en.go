@@ -0,0 +1,44 @@
+package samples
+
+var English = `All human beings are born free and equal in dignity and rights. They are endowed with reason
+and conscience and should act towards one another in a spirit of brotherhood. The weather was cold this morning,
+so we stayed at home and read books by the fire. Search engines build an index of the words found in every
+document, which makes it possible to find the most relevant pages quickly. Children learn languages by listening
+to the people around them and by trying to speak themselves.
+Every morning my neighbour walks her dog along the river before she goes to work. The city library opens at nine
+o'clock and closes late in the evening, and it is often full of students who prepare for their exams. We usually
+eat dinner together and talk about what happened during the day.
+Last summer we rented a cabin by a lake in the mountains. There was no electricity, so we cooked on a wood stove
+and fetched water from the spring behind the house. In the evenings we sat on the porch and watched the sunset
+while the mosquitoes buzzed around us. The children went swimming every day, even when it rained, and they learned
+to row the old boat out to the little island in the middle of the lake. I had planned to finish my book, but I
+never got further than the second chapter.
+Yesterday the government presented a new proposal on how local councils should pay for the care of the elderly.
+Under the proposal, councils with many older residents would receive a larger grant from the state, while those
+that are growing quickly would get less. The opposition criticised the plan and said that it does not solve the
+shortage of nurses. The question will now be studied further, and no decision is expected until after the
+election in the autumn.
+My grandmother was born on a farm outside a small town in the north and grew up with seven brothers and sisters.
+She often told us about the long winters, when the snow was a metre deep and they had to dig their way out of the
+door in the morning. She learned to knit when she was five years old and never stopped, not even when she turned
+ninety. I still have several of her sweaters and mittens, and I think of her every time I put them on.
+It is hard to find a flat in London, especially if you are young and have not saved any money. Many students
+rent rooms and have to move several times a year. Prices have risen sharply, and anyone who wants to buy a home
+often needs help from their parents with the deposit. The politicians have promised to build more affordable
+housing, but progress has been slow.
+The company has developed an app that helps its users keep track of how much electricity they use. The app shows
+how much power the household consumes at different hours of the day and suggests when it is cheapest to do the
+washing or charge the car. According to the company, an ordinary family could save hundreds of pounds a year.
+The service is free for the first six months, after which it costs a few pounds a month.
+When I was a little boy, I wanted to become a train driver. I collected old tickets and could recite all the
+stations between London and Edinburgh by heart. Later I became interested in birds instead, and now I work as a
+biologist at a nature reserve on the coast. Every spring we count the cranes that rest in the fields before they
+fly further north, and this year there were more of them than ever before.
+We had a barbecue in the garden in August and sang songs until it got dark. The neighbours came over with
+homemade bread and a big piece of cheese, and somebody had brought a guitar. It was one of the best evenings in a
+long time, and nobody wanted to go home. Next year we will try to throw the party again, but in my parents'
+garden, because it is bigger.
+This autumn the school will introduce a new scheme for homework help. Pupils can stay after their lessons and get
+support from teachers and older pupils in maths, English and science. The head teacher hopes that more of them
+will pass their exams and that fewer will have to repeat a year. The parents are positive, but some of them
+wonder how the teachers will find the time.`
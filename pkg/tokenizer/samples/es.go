@@ -0,0 +1,45 @@
+package samples
+
+var Spanish = `Todos los seres humanos nacen libres e iguales en dignidad y derechos y, dotados como están de
+razón y conciencia, deben comportarse fraternalmente los unos con los otros. El tiempo estaba frío esta mañana,
+así que nos quedamos en casa leyendo libros junto al fuego. Los motores de búsqueda construyen un índice de las
+palabras que aparecen en cada documento, lo que permite encontrar rápidamente las páginas más relevantes.
+Los niños aprenden los idiomas escuchando a las personas que los rodean e intentando hablar ellos mismos.
+Cada mañana mi vecina pasea a su perro a lo largo del río antes de ir al trabajo. La biblioteca de la ciudad abre
+a las nueve y cierra tarde por la noche, y a menudo está llena de estudiantes que preparan sus exámenes. Normalmente
+cenamos juntos y hablamos de lo que ha pasado durante el día.
+El verano pasado alquilamos una cabaña junto a un lago en la montaña. No había electricidad, así que cocinábamos en
+una estufa de leña y sacábamos el agua del manantial que había detrás de la casa. Por las tardes nos sentábamos en
+el porche a mirar la puesta de sol mientras los mosquitos zumbaban a nuestro alrededor. Los niños se bañaban todos
+los días, incluso cuando llovía, y aprendieron a remar con la vieja barca hasta la pequeña isla que hay en medio del
+lago. Yo pensaba terminar mi libro, pero nunca pasé del segundo capítulo.
+Ayer el gobierno presentó una nueva propuesta sobre cómo deben financiar los ayuntamientos el cuidado de las
+personas mayores. Según la propuesta, los municipios con muchos habitantes mayores recibirían una ayuda más grande
+del Estado, mientras que los que crecen deprisa recibirían menos. La oposición criticó el plan y dijo que no
+resuelve la falta de enfermeras. El asunto se estudiará ahora con más detalle, y no se espera ninguna decisión hasta
+después de las elecciones de otoño.
+Mi abuela nació en una granja cerca de un pueblo pequeño del norte y se crió con siete hermanos. Nos contaba a
+menudo cómo eran los largos inviernos, cuando la nieve llegaba a un metro de altura y por la mañana tenían que
+abrirse paso con la pala para salir por la puerta. Aprendió a tejer cuando tenía cinco años y nunca lo dejó, ni
+siquiera cuando cumplió noventa. Todavía tengo varios de sus jerséis y manoplas, y me acuerdo de ella cada vez que
+me los pongo.
+Es difícil encontrar un piso en Madrid, sobre todo si eres joven y no tienes ahorros. Muchos estudiantes alquilan
+una habitación y tienen que mudarse varias veces al año. Los alquileres han subido mucho, y quien quiere comprar
+una vivienda suele necesitar la ayuda de sus padres. Los políticos han prometido construir más viviendas
+asequibles, pero todo va muy despacio.
+La empresa ha desarrollado una aplicación que ayuda a sus usuarios a controlar su consumo de electricidad. La
+aplicación muestra cuánta energía gasta el hogar a las distintas horas del día y sugiere cuándo es más barato
+poner la lavadora o cargar el coche. Según la empresa, una familia normal podría ahorrar varios cientos de euros
+al año. El servicio es gratuito durante los primeros seis meses y después cuesta cuatro euros al mes.
+Cuando era pequeño, quería ser maquinista de tren. Coleccionaba billetes viejos y me sabía de memoria todas las
+estaciones entre Madrid y Barcelona. Más tarde empezaron a interesarme los pájaros, y ahora trabajo como biólogo
+en una reserva natural de la costa. Cada primavera contamos las grullas que descansan en los campos antes de
+seguir volando hacia el norte, y este año había más que nunca.
+En agosto hicimos una barbacoa en el jardín y cantamos canciones hasta que se hizo de noche. Los vecinos vinieron
+con pan casero y un buen trozo de queso, y alguien había traído una guitarra. Fue una de las mejores noches en
+mucho tiempo, y nadie quería irse a casa. El año que viene intentaremos repetir la fiesta, pero en el jardín de
+mis padres, porque es más grande.
+Este otoño el colegio pondrá en marcha un nuevo programa de ayuda con los deberes. Los alumnos podrán quedarse
+después de las clases y recibir apoyo de los profesores y de los alumnos mayores en matemáticas, lengua e inglés.
+La directora espera que aprueben más alumnos y que menos tengan que repetir curso. Los padres están contentos,
+pero algunos se preguntan de dónde sacarán tiempo los profesores.`
@@ -0,0 +1,42 @@
+package samples
+
+var Finnish = `Kaikki ihmiset syntyvät vapaina ja tasavertaisina arvoltaan ja oikeuksiltaan. Heille on annettu järki
+ja omatunto, ja heidän on toimittava toisiaan kohtaan veljeyden hengessä. Sää oli kylmä tänä aamuna, joten jäimme
+kotiin lukemaan kirjoja takan ääreen. Hakukoneet rakentavat hakemiston jokaisessa asiakirjassa esiintyvistä
+sanoista, minkä ansiosta tärkeimmät sivut löytyvät nopeasti. Lapset oppivat kieliä kuuntelemalla ympärillään
+olevia ihmisiä ja yrittämällä puhua itse.
+Joka aamu naapurini ulkoiluttaa koiraansa joen rannalla ennen kuin hän lähtee töihin. Kaupungin kirjasto aukeaa
+kello yhdeksän ja sulkeutuu myöhään illalla, ja se on usein täynnä opiskelijoita, jotka valmistautuvat tentteihinsä.
+Syömme yleensä illallista yhdessä ja puhumme siitä, mitä päivän aikana tapahtui.
+Viime kesänä vuokrasimme mökin järven rannalta Lapista. Siellä ei ollut sähköä, joten teimme ruoan puuhellalla ja
+haimme veden lähteestä talon takaa. Iltaisin istuimme laiturilla katselemassa auringonlaskua, kun hyttyset
+surisivat ympärillämme. Lapset uivat joka päivä, myös sateella, ja he oppivat soutamaan vanhalla veneellä pienelle
+saarelle keskellä järveä. Olin aikonut kirjoittaa kirjani valmiiksi, mutta en päässyt toista lukua pidemmälle.
+Hallitus esitteli eilen uuden ehdotuksen siitä, miten kuntien pitäisi rahoittaa vanhustenhoito. Ehdotuksen mukaan
+kunnat, joissa asuu paljon iäkkäitä ihmisiä, saisivat valtiolta suuremman avustuksen, kun taas nopeasti kasvavat
+kunnat saisivat vähemmän. Oppositio arvosteli suunnitelmaa ja sanoi, ettei se ratkaise hoitajapulaa. Asiaa
+selvitetään nyt tarkemmin, eikä päätöstä odoteta ennen syksyn vaaleja.
+Isoäitini syntyi maatilalla pienen kylän lähellä pohjoisessa ja kasvoi seitsemän sisaruksen kanssa. Hän kertoi
+usein pitkistä talvista, jolloin lunta oli metrin verran ja aamulla ovelta piti lapioida tie ulos. Hän oppi
+neulomaan viisivuotiaana eikä koskaan lopettanut, ei edes täytettyään yhdeksänkymmentä. Minulla on vieläkin monta
+hänen villapaitaansa ja lapastaan, ja ajattelen häntä aina, kun puen ne päälleni.
+Helsingistä on vaikea löytää asuntoa, varsinkin jos on nuori eikä ole säästänyt rahaa. Monet opiskelijat
+vuokraavat huoneen ja joutuvat muuttamaan useita kertoja vuodessa. Vuokrat ovat nousseet jyrkästi, ja joka haluaa
+ostaa asunnon, tarvitsee usein vanhempiensa apua. Poliitikot ovat luvanneet rakentaa lisää kohtuuhintaisia
+asuntoja, mutta työ etenee hitaasti.
+Yritys on kehittänyt sovelluksen, jonka avulla käyttäjät voivat seurata sähkönkulutustaan. Sovellus näyttää,
+kuinka paljon sähköä kotitalous kuluttaa vuorokauden eri tunteina, ja ehdottaa, milloin pyykinpesu tai auton
+lataaminen on edullisinta. Yrityksen mukaan tavallinen perhe voisi säästää satoja euroja vuodessa. Palvelu on
+ilmainen ensimmäisen puolen vuoden ajan, minkä jälkeen se maksaa neljä euroa kuukaudessa.
+Kun olin pieni, halusin veturinkuljettajaksi. Keräsin vanhoja junalippuja ja osasin luetella ulkoa kaikki asemat
+Helsingin ja Oulun välillä. Myöhemmin kiinnostuin sen sijaan linnuista, ja nyt työskentelen biologina
+luonnonsuojelualueella rannikolla. Joka kevät laskemme kurjet, jotka lepäävät pelloilla ennen kuin jatkavat
+lentoaan pohjoiseen, ja tänä vuonna niitä oli enemmän kuin koskaan aiemmin.
+Elokuussa grillasimme puutarhassa ja lauloimme, kunnes tuli pimeää. Naapurit tulivat kylään kotona leivotun
+leivän ja ison juustonpalan kanssa, ja joku oli tuonut kitaran. Se oli yksi pitkän ajan parhaista illoista, eikä
+kukaan halunnut lähteä kotiin. Ensi vuonna yritämme järjestää juhlat uudelleen, mutta vanhempieni puutarhassa,
+koska se on isompi.
+Koulu ottaa tänä syksynä käyttöön uuden läksyapujärjestelmän. Oppilaat voivat jäädä tuntien jälkeen kouluun ja
+saada apua opettajilta ja vanhemmilta oppilailta matematiikassa, äidinkielessä ja englannissa. Rehtori toivoo,
+että useampi oppilas pääsee läpi ja harvempi joutuu kertaamaan luokan. Vanhemmat ovat tyytyväisiä, mutta jotkut
+miettivät, mistä opettajat löytävät siihen aikaa.`
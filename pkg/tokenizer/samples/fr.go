@@ -0,0 +1,49 @@
+package samples
+
+var French = `Tous les êtres humains naissent libres et égaux en dignité et en droits. Ils sont doués de raison
+et de conscience et doivent agir les uns envers les autres dans un esprit de fraternité. Le temps était froid
+ce matin, alors nous sommes restés à la maison pour lire des livres près du feu. Les moteurs de recherche
+construisent un index des mots trouvés dans chaque document, ce qui permet de retrouver rapidement les pages
+les plus pertinentes. Les enfants apprennent les langues en écoutant les personnes qui les entourent et en
+essayant de parler eux-mêmes.
+Chaque matin, ma voisine promène son chien le long de la rivière avant d'aller au travail. La bibliothèque de la
+ville ouvre à neuf heures et ferme tard le soir, et elle est souvent pleine d'étudiants qui préparent leurs examens.
+Nous dînons généralement ensemble et nous parlons de ce qui s'est passé pendant la journée.
+L'été dernier, nous avons loué un chalet au bord d'un lac dans les montagnes. Il n'y avait pas d'électricité, donc
+nous faisions la cuisine sur un poêle à bois et nous allions chercher l'eau à la source derrière la maison. Le soir,
+nous nous asseyions sur la terrasse pour regarder le coucher du soleil pendant que les moustiques bourdonnaient
+autour de nous. Les enfants se baignaient tous les jours, même quand il pleuvait, et ils ont appris à ramer jusqu'à
+la petite île au milieu du lac avec la vieille barque. J'avais prévu de terminer mon livre, mais je ne suis jamais
+allé plus loin que le deuxième chapitre.
+Hier, le gouvernement a présenté une nouvelle proposition sur la manière dont les communes doivent financer la prise
+en charge des personnes âgées. Selon ce projet, les communes qui comptent beaucoup d'habitants âgés recevraient une
+aide plus importante de l'État, tandis que celles qui grandissent rapidement en recevraient moins. L'opposition a
+critiqué le plan et affirmé qu'il ne règle pas le manque d'infirmières. La question sera maintenant étudiée plus en
+détail, et aucune décision n'est attendue avant les élections de l'automne.
+Ma grand-mère est née dans une ferme près d'un petit village du nord et elle a grandi avec sept frères et sœurs.
+Elle nous racontait souvent les longs hivers, quand la neige atteignait un mètre et qu'il fallait dégager la porte
+à la pelle le matin. Elle a appris à tricoter à l'âge de cinq ans et n'a jamais arrêté, pas même lorsqu'elle a eu
+quatre-vingt-dix ans. J'ai encore plusieurs de ses pulls et de ses moufles, et je pense à elle chaque fois que je
+les mets.
+Il est difficile de trouver un appartement à Paris, surtout quand on est jeune et qu'on n'a pas d'économies.
+Beaucoup d'étudiants louent une chambre et doivent déménager plusieurs fois par an. Les loyers ont fortement
+augmenté, et ceux qui veulent acheter un logement ont souvent besoin de l'aide de leurs parents. Les responsables
+politiques ont promis de construire davantage de logements sociaux, mais les choses avancent lentement.
+L'entreprise a développé une application qui aide ses utilisateurs à suivre leur consommation d'électricité.
+L'application indique combien d'énergie le foyer consomme aux différentes heures de la journée et propose les
+moments où il est le moins cher de faire la lessive ou de recharger la voiture. Selon l'entreprise, une famille
+ordinaire pourrait ainsi économiser plusieurs centaines d'euros par an. Le service est gratuit pendant les six
+premiers mois, puis il coûte quatre euros par mois.
+Quand j'étais petit, je voulais devenir conducteur de train. Je collectionnais les vieux billets et je pouvais
+réciter par cœur toutes les gares entre Lille et Marseille. Plus tard, je me suis plutôt intéressé aux oiseaux,
+et aujourd'hui je travaille comme biologiste dans une réserve naturelle sur la côte. Chaque printemps, nous
+comptons les grues qui se reposent dans les champs avant de repartir vers le nord, et cette année elles étaient
+plus nombreuses que jamais.
+Au mois d'août, nous avons fait un barbecue dans le jardin et nous avons chanté jusqu'à la tombée de la nuit. Les
+voisins sont venus avec du pain fait maison et un gros morceau de fromage, et quelqu'un avait apporté une guitare.
+C'était l'une des plus belles soirées depuis longtemps, et personne ne voulait rentrer. L'année prochaine, nous
+essaierons d'organiser la fête à nouveau, mais dans le jardin de mes parents, parce qu'il est plus grand.
+Cet automne, l'école va mettre en place un nouveau dispositif d'aide aux devoirs. Les élèves pourront rester après
+les cours et recevoir l'aide des professeurs et des élèves plus âgés en mathématiques, en français et en anglais.
+La directrice espère que davantage d'élèves réussiront leurs examens et que moins d'entre eux redoubleront. Les
+parents sont enthousiastes, mais certains se demandent où les enseignants trouveront le temps.`
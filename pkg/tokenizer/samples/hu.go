@@ -0,0 +1,43 @@
+package samples
+
+var Hungarian = `Minden emberi lény szabadon születik és egyenlő méltósága és joga van. Az emberek, ésszel és
+lelkiismerettel bírván, egymással szemben testvéri szellemben kell hogy viseltessenek. Ma reggel hideg volt az idő,
+ezért otthon maradtunk, és a tűz mellett könyveket olvastunk. A keresőmotorok minden dokumentum szavaiból indexet
+építenek, így gyorsan megtalálhatók a legfontosabb oldalak. A gyerekek úgy tanulnak nyelveket, hogy hallgatják
+a körülöttük élő embereket, és maguk is megpróbálnak beszélni.
+Minden reggel a szomszédom sétáltatja a kutyáját a folyó mentén, mielőtt munkába megy. A városi könyvtár kilenc
+órakor nyit és késő este zár, és gyakran tele van diákokkal, akik a vizsgáikra készülnek. Általában együtt
+vacsorázunk, és megbeszéljük, mi történt a nap folyamán.
+Tavaly nyáron kibéreltünk egy faházat egy hegyi tó partján. Nem volt áram, ezért fatüzelésű tűzhelyen főztünk, és
+a ház mögötti forrásból hoztuk a vizet. Esténként a verandán ültünk, és néztük a naplementét, miközben a szúnyogok
+zümmögtek körülöttünk. A gyerekek minden nap fürödtek, akkor is, amikor esett az eső, és megtanultak elevezni a
+régi csónakkal a tó közepén lévő kis szigetig. Azt terveztem, hogy befejezem a könyvemet, de nem jutottam tovább
+a második fejezetnél.
+A kormány tegnap új javaslatot terjesztett elő arról, hogyan finanszírozzák az önkormányzatok az idősek
+gondozását. A javaslat szerint azok a települések, ahol sok idős ember él, nagyobb támogatást kapnának az
+államtól, a gyorsan növekvő települések pedig kevesebbet. Az ellenzék bírálta a tervet, és azt mondta, hogy nem
+oldja meg az ápolóhiányt. A kérdést most tovább vizsgálják, és döntés csak az őszi választások után várható.
+A nagymamám egy tanyán született egy kis falu mellett az ország északi részén, és hét testvérrel nőtt fel. Gyakran
+mesélt a hosszú telekről, amikor egy méter magas volt a hó, és reggelente ki kellett lapátolniuk magukat az
+ajtón. Ötéves korában tanult meg kötni, és soha nem hagyta abba, még kilencvenéves korában sem. Még mindig
+megvan néhány pulóvere és kesztyűje, és mindig rá gondolok, amikor felveszem őket.
+Nehéz lakást találni Budapesten, különösen akkor, ha az ember fiatal, és nincs megtakarított pénze. Sok egyetemista
+albérletben lakik, és évente többször is költöznie kell. Az árak jelentősen emelkedtek, és aki lakást szeretne
+venni, annak gyakran a szülei segítségére van szüksége. A politikusok megígérték, hogy több megfizethető lakást
+építenek, de a munka lassan halad.
+A cég kifejlesztett egy alkalmazást, amelynek segítségével a felhasználók nyomon követhetik az áramfogyasztásukat.
+Az alkalmazás megmutatja, mennyi áramot fogyaszt a háztartás a nap különböző óráiban, és javaslatot tesz arra,
+mikor a legolcsóbb mosni vagy feltölteni az autót. A cég szerint egy átlagos család így évente több tízezer
+forintot takaríthat meg. A szolgáltatás az első fél évben ingyenes, utána havonta néhány száz forintba kerül.
+Kisfiú koromban mozdonyvezető szerettem volna lenni. Régi vonatjegyeket gyűjtöttem, és fejből fel tudtam sorolni
+az összes állomást Budapest és Debrecen között. Később inkább a madarak kezdtek érdekelni, és ma biológusként
+dolgozom egy természetvédelmi területen a tó partján. Minden tavasszal megszámoljuk a darvakat, amelyek a
+földeken pihennek, mielőtt tovább repülnek észak felé, és az idén többen voltak, mint valaha.
+Augusztusban a kertben grilleztünk, és addig énekeltünk, amíg be nem sötétedett. A szomszédok házi kenyérrel és
+egy nagy darab sajttal jöttek át, és valaki gitárt is hozott. Régóta az egyik legszebb esténk volt, és senki sem
+akart hazamenni. Jövőre megpróbáljuk újra megrendezni az ünnepséget, de akkor a szüleim kertjében, mert az
+nagyobb.
+Az iskola ősszel új házifeladat-segítő programot indít. A diákok az órák után bent maradhatnak, és a tanárok meg
+az idősebb diákok segítenek nekik matematikából, magyarból és angolból. Az igazgató reméli, hogy többen fognak
+sikeresen vizsgázni, és kevesebben kell majd évet ismételniük. A szülők örülnek, de néhányan azon tűnődnek,
+honnan lesz erre a tanároknak ideje.`
@@ -0,0 +1,44 @@
+package samples
+
+var Italian = `Tutti gli esseri umani nascono liberi ed eguali in dignità e diritti. Essi sono dotati di ragione e di
+coscienza e devono agire gli uni verso gli altri in spirito di fratellanza. Stamattina faceva freddo, così siamo
+rimasti a casa a leggere libri vicino al fuoco. I motori di ricerca costruiscono un indice delle parole trovate in
+ogni documento, che permette di trovare rapidamente le pagine più pertinenti. I bambini imparano le lingue
+ascoltando le persone che li circondano e provando a parlare da soli.
+Ogni mattina la mia vicina porta a spasso il cane lungo il fiume prima di andare al lavoro. La biblioteca della
+città apre alle nove e chiude tardi la sera, ed è spesso piena di studenti che preparano i loro esami. Di solito
+ceniamo insieme e parliamo di quello che è successo durante la giornata.
+L'estate scorsa abbiamo affittato una baita vicino a un lago in montagna. Non c'era la corrente elettrica, quindi
+cucinavamo su una stufa a legna e prendevamo l'acqua dalla sorgente dietro la casa. La sera ci sedevamo sotto il
+portico a guardare il tramonto mentre le zanzare ci ronzavano intorno. I bambini facevano il bagno tutti i giorni,
+anche quando pioveva, e hanno imparato a remare con la vecchia barca fino alla piccola isola in mezzo al lago.
+Avevo intenzione di finire il mio libro, ma non sono mai andato oltre il secondo capitolo.
+Ieri il governo ha presentato una nuova proposta su come i comuni devono finanziare l'assistenza agli anziani.
+Secondo la proposta, i comuni con molti abitanti anziani riceverebbero un contributo più alto dallo Stato, mentre
+quelli che crescono in fretta ne riceverebbero meno. L'opposizione ha criticato il piano e ha detto che non risolve
+la carenza di infermieri. La questione sarà ora esaminata più a fondo, e non si attende nessuna decisione prima
+delle elezioni d'autunno.
+Mia nonna è nata in una fattoria vicino a un piccolo paese del nord ed è cresciuta con sette fratelli e sorelle.
+Ci raccontava spesso dei lunghi inverni, quando la neve arrivava a un metro e la mattina dovevano spalare per
+uscire dalla porta. Ha imparato a lavorare a maglia a cinque anni e non ha mai smesso, neanche quando ha compiuto
+novant'anni. Ho ancora parecchi dei suoi maglioni e delle sue muffole, e penso a lei ogni volta che li indosso.
+È difficile trovare un appartamento a Milano, soprattutto se si è giovani e non si hanno risparmi. Molti studenti
+affittano una stanza e devono traslocare più volte all'anno. Gli affitti sono aumentati molto, e chi vuole
+comprare una casa ha spesso bisogno dell'aiuto dei genitori. I politici hanno promesso di costruire più case a
+prezzi accessibili, ma le cose procedono lentamente.
+L'azienda ha sviluppato un'applicazione che aiuta gli utenti a tenere sotto controllo il consumo di elettricità.
+L'applicazione mostra quanta energia consuma la famiglia nelle diverse ore della giornata e suggerisce quando
+conviene fare il bucato o ricaricare la macchina. Secondo l'azienda, una famiglia normale potrebbe risparmiare
+alcune centinaia di euro all'anno. Il servizio è gratuito per i primi sei mesi, poi costa quattro euro al mese.
+Quando ero piccolo volevo fare il macchinista. Collezionavo vecchi biglietti del treno e sapevo a memoria tutte le
+stazioni tra Milano e Napoli. Più tardi ho cominciato a interessarmi agli uccelli, e adesso lavoro come biologo in
+una riserva naturale sulla costa. Ogni primavera contiamo le gru che si riposano nei campi prima di ripartire
+verso nord, e quest'anno erano più numerose che mai.
+Ad agosto abbiamo fatto una grigliata in giardino e abbiamo cantato fino a quando è diventato buio. I vicini sono
+arrivati con del pane fatto in casa e un bel pezzo di formaggio, e qualcuno aveva portato una chitarra. È stata
+una delle serate più belle da tanto tempo, e nessuno voleva tornare a casa. L'anno prossimo proveremo a rifare la
+festa, ma nel giardino dei miei genitori, perché è più grande.
+Quest'autunno la scuola introdurrà un nuovo servizio di aiuto per i compiti. Gli alunni potranno restare dopo le
+lezioni e farsi aiutare dagli insegnanti e dagli studenti più grandi in matematica, italiano e inglese. La preside
+spera che più ragazzi vengano promossi e che meno debbano ripetere l'anno. I genitori sono contenti, ma alcuni si
+chiedono dove gli insegnanti troveranno il tempo.`
@@ -0,0 +1,44 @@
+package samples
+
+var Dutch = `Alle mensen worden vrij en gelijk in waardigheid en rechten geboren. Zij zijn begiftigd met verstand en
+geweten, en behoren zich jegens elkaar in een geest van broederschap te gedragen. Het weer was koud vanochtend, dus
+zijn we thuis gebleven en hebben we bij het vuur boeken gelezen. Zoekmachines bouwen een index van de woorden die in
+elk document voorkomen, waardoor de meest relevante pagina's snel gevonden kunnen worden. Kinderen leren talen door
+te luisteren naar de mensen om hen heen en door zelf te proberen te spreken.
+Elke ochtend laat mijn buurvrouw haar hond uit langs de rivier voordat ze naar haar werk gaat. De stadsbibliotheek
+gaat om negen uur open en sluit laat in de avond, en zit vaak vol met studenten die zich voorbereiden op hun
+examens. Meestal eten we samen en praten we over wat er tijdens de dag is gebeurd.
+Vorige zomer hebben we een huisje aan een meer in de bergen gehuurd. Er was geen elektriciteit, dus kookten we op
+een houtkachel en haalden we water uit de bron achter het huis. 's Avonds zaten we op de veranda en keken we naar
+de zonsondergang, terwijl de muggen om ons heen zoemden. De kinderen gingen elke dag zwemmen, ook als het regende,
+en ze leerden de oude boot naar het kleine eiland midden in het meer te roeien. Ik was van plan mijn boek af te
+maken, maar ik ben niet verder gekomen dan het tweede hoofdstuk.
+De regering heeft gisteren een nieuw voorstel gepresenteerd over de manier waarop gemeenten de zorg voor ouderen
+moeten betalen. Volgens het voorstel krijgen gemeenten met veel oudere inwoners een grotere bijdrage van het rijk,
+terwijl snelgroeiende gemeenten minder krijgen. De oppositie had kritiek op het plan en zei dat het het tekort aan
+verpleegkundigen niet oplost. De kwestie wordt nu verder onderzocht, en een besluit wordt pas na de verkiezingen in
+het najaar verwacht.
+Mijn oma werd geboren op een boerderij in de buurt van een klein dorp in het noorden en groeide op met zeven broers
+en zussen. Ze vertelde vaak over de lange winters, toen de sneeuw een meter hoog lag en ze 's ochtends de deur uit
+moesten scheppen. Ze leerde breien toen ze vijf jaar oud was en is er nooit mee gestopt, zelfs niet toen ze negentig
+werd. Ik heb nog steeds een paar van haar truien en wanten, en ik denk elke keer aan haar als ik ze aantrek.
+Het is moeilijk om in Amsterdam een woning te vinden, vooral als je jong bent en geen geld hebt gespaard. Veel
+studenten huren een kamer en moeten een paar keer per jaar verhuizen. De huren zijn flink gestegen, en wie een
+huis wil kopen, heeft vaak hulp nodig van zijn ouders. De politiek heeft beloofd meer betaalbare woningen te
+bouwen, maar dat gaat langzaam.
+Het bedrijf heeft een app ontwikkeld waarmee gebruikers hun stroomverbruik kunnen bijhouden. De app laat zien
+hoeveel stroom het huishouden op verschillende momenten van de dag gebruikt en geeft aan wanneer het het goedkoopst
+is om de was te doen of de auto op te laden. Volgens het bedrijf kan een gewoon gezin zo honderden euro's per jaar
+besparen. De dienst is het eerste halfjaar gratis, daarna kost hij vier euro per maand.
+Toen ik klein was, wilde ik machinist worden. Ik verzamelde oude treinkaartjes en kon alle stations tussen
+Groningen en Maastricht uit mijn hoofd opnoemen. Later raakte ik in plaats daarvan geïnteresseerd in vogels, en
+nu werk ik als bioloog in een natuurgebied aan de kust. Elk voorjaar tellen we de kraanvogels die op de akkers
+uitrusten voordat ze verder naar het noorden vliegen, en dit jaar waren het er meer dan ooit.
+In augustus hebben we in de tuin gebarbecued en liedjes gezongen tot het donker werd. De buren kwamen langs met
+zelfgebakken brood en een groot stuk kaas, en iemand had een gitaar meegenomen. Het was een van de mooiste avonden
+in lange tijd, en niemand wilde naar huis. Volgend jaar willen we het feest opnieuw geven, maar dan in de tuin van
+mijn ouders, omdat die groter is.
+De school gaat dit najaar een nieuwe regeling voor huiswerkbegeleiding invoeren. Leerlingen kunnen na de lessen
+blijven en hulp krijgen van leraren en oudere leerlingen bij wiskunde, Nederlands en Engels. De directeur hoopt
+dat meer leerlingen hun diploma halen en dat minder van hen blijven zitten. De ouders zijn enthousiast, maar
+sommigen vragen zich af hoe de leraren daar tijd voor moeten vinden.`
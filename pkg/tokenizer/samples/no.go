@@ -0,0 +1,46 @@
+package samples
+
+var Norwegian = `Alle mennesker er født frie og med samme menneskeverd og menneskerettigheter. De er utstyrt med
+fornuft og samvittighet og bør handle mot hverandre i brorskapets ånd. Været var kaldt i morges, så vi ble hjemme
+og leste bøker ved peisen. Søkemotorer bygger en indeks over ordene som finnes i hvert dokument, noe som gjør det
+mulig å finne de mest relevante sidene raskt. Barn lærer språk ved å lytte til menneskene rundt seg og ved å
+prøve å snakke selv.
+Hver morgen går naboen min tur med hunden sin langs elva før hun drar på jobb. Byens bibliotek åpner klokka ni og
+stenger sent på kvelden, og det er ofte fullt av studenter som forbereder seg til eksamen. Vi spiser vanligvis middag
+sammen og snakker om hva som har skjedd i løpet av dagen.
+I fjor sommer leide vi ei hytte ved et vann på fjellet. Det fantes ikke strøm der, så vi laget maten på en vedovn og
+hentet vann fra bekken bak huset. Om kveldene satt vi ute på trappa og så på solnedgangen mens myggen surret rundt
+oss. Ungene badet hver dag, også når det regnet, og de lærte seg å ro den gamle båten ut til den lille holmen midt
+i vannet. Jeg hadde tenkt å skrive ferdig boka mi, men jeg kom ikke lenger enn til det andre kapittelet.
+Regjeringen la i går fram et nytt forslag om hvordan kommunene skal finansiere eldreomsorgen. Ifølge forslaget
+skal kommuner med mange eldre innbyggere få et større tilskudd fra staten, mens de som vokser raskt får mindre.
+Opposisjonen kritiserte forslaget og mente at det ikke løser problemet med mangelen på sykepleiere. Saken skal nå
+utredes videre, og det ventes ikke noe vedtak før etter valget til høsten.
+Bestemora mi ble født på en gård utenfor Tromsø og vokste opp med sju søsken. Hun fortalte ofte om de lange
+vintrene, da snøen lå meterhøy og de måtte måke seg ut gjennom døra om morgenen. Hun lærte å strikke da hun var
+fem år gammel og sluttet aldri, ikke engang da hun ble nitti. Fremdeles har jeg flere av genserne og vottene
+hennes, og jeg tenker på henne hver gang jeg tar dem på meg.
+Det er vanskelig å finne en leilighet i Oslo, særlig hvis man er ung og ikke har spart opp egenkapital. Mange
+studenter leier hybler og må flytte flere ganger i året. Prisene har steget kraftig, og den som vil kjøpe bolig
+trenger ofte hjelp fra foreldrene sine. Politikerne har lovet å bygge flere utleieboliger, men det går sakte.
+Selskapet har utviklet ei app som hjelper brukerne med å holde oversikt over strømforbruket sitt. Appen viser hvor
+mye strøm som brukes i husholdningen i løpet av døgnet og foreslår når det er billigst å vaske klær eller lade
+bilen. Ifølge selskapet kan en vanlig familie spare flere tusen kroner i året. Tjenesten er gratis det første
+halvåret, deretter koster den førti kroner i måneden.
+Da jeg var liten, ville jeg bli lokomotivfører. Jeg samlet på togbilletter og kunne ramse opp alle stasjonene
+mellom Bergen og Bodø utenat. Senere ble jeg interessert i fugler i stedet, og nå jobber jeg som biolog i et
+naturreservat ved kysten. Hver vår teller vi tranene som hviler på jordene før de flyr videre nordover, og i år var
+det flere enn noen gang tidligere.
+Vi spiste reker på brygga i august og sang til det ble mørkt. Naboene kom bort med hjemmebakt brød og en stor
+brunost, og noen hadde tatt med seg ei gitar. Det var en av de beste kveldene på lenge, og ingen ville gå hjem.
+Neste år skal vi prøve å arrangere festen igjen, men da i hagen til foreldrene mine, fordi den er større.
+Skolen skal i høst innføre en ny ordning for leksehjelp. Elevene kan bli igjen etter timene og få hjelp av lærere
+og eldre elever i matematikk, norsk og engelsk. Rektor håper at flere skal bestå, og at færre må ta et år om igjen.
+Foreldrene er positive, men noen lurer på hvordan lærerne skal rekke over alt sammen. Hva synes dere om det?
+På lørdagene er parken full av folk. Ungene leker i sandkassa eller løper etter duene, mens foreldrene sitter på
+benkene og drikker kaffe. En gammel mann mater endene ved dammen, og noen ungdommer spiller fotball på plenen.
+Hunden vil alltid være med, men den får ikke bade i dammen, så den bjeffer på fuglene i stedet. Jeg liker å gå dit
+tidlig, når det fortsatt er stille og sola nettopp har stått opp. Av og til tar jeg med meg ei bok, men som regel
+sitter jeg bare og ser på folkene som går forbi. Det føles som om byen våkner sakte rundt meg. Hvorfor skulle man
+bli inne når det er så fint ute? Hvor mye koster en kopp kaffe i kiosken nå for tiden? Ikke særlig mye, men den
+smaker bedre enn hjemme. Etterpå handler jeg mat i butikken på hjørnet og sykler hjem gjennom skogen.`
@@ -0,0 +1,43 @@
+package samples
+
+var Portuguese = `Todos os seres humanos nascem livres e iguais em dignidade e em direitos. Dotados de razão e de
+consciência, devem agir uns para com os outros em espírito de fraternidade. O tempo estava frio esta manhã, por isso
+ficámos em casa a ler livros junto da lareira. Os motores de busca constroem um índice das palavras encontradas em
+cada documento, o que permite encontrar rapidamente as páginas mais relevantes. As crianças aprendem as línguas
+ouvindo as pessoas à sua volta e tentando falar sozinhas.
+Todas as manhãs a minha vizinha passeia o cão ao longo do rio antes de ir trabalhar. A biblioteca da cidade abre às
+nove horas e fecha tarde à noite, e está muitas vezes cheia de estudantes que preparam os seus exames. Normalmente
+jantamos juntos e conversamos sobre o que aconteceu durante o dia.
+No verão passado alugámos uma cabana junto a um lago nas montanhas. Não havia eletricidade, por isso cozinhávamos
+num fogão a lenha e íamos buscar água à nascente atrás da casa. À noite sentávamo-nos no alpendre a ver o pôr do
+sol enquanto os mosquitos zumbiam à nossa volta. As crianças tomavam banho todos os dias, mesmo quando chovia, e
+aprenderam a remar no velho barco até à pequena ilha no meio do lago. Eu tinha planeado acabar o meu livro, mas
+nunca passei do segundo capítulo.
+Ontem o governo apresentou uma nova proposta sobre a forma como as câmaras municipais devem pagar os cuidados aos
+idosos. Segundo a proposta, os municípios com muitos habitantes idosos receberiam um apoio maior do Estado,
+enquanto os que crescem depressa receberiam menos. A oposição criticou o plano e disse que ele não resolve a falta
+de enfermeiros. A questão vai agora ser estudada com mais cuidado, e não se espera nenhuma decisão antes das
+eleições do outono.
+A minha avó nasceu numa quinta perto de uma pequena aldeia do norte e cresceu com sete irmãos. Contava-nos muitas
+vezes como eram os longos invernos, quando a neve chegava a um metro de altura e de manhã era preciso abrir
+caminho com a pá para sair pela porta. Aprendeu a fazer malha quando tinha cinco anos e nunca parou, nem sequer
+quando fez noventa. Ainda tenho várias das suas camisolas e luvas, e lembro-me dela sempre que as visto.
+É difícil encontrar uma casa em Lisboa, sobretudo quando se é jovem e não se tem poupanças. Muitos estudantes
+arrendam um quarto e têm de mudar de casa várias vezes por ano. As rendas subiram muito, e quem quer comprar uma
+casa precisa muitas vezes da ajuda dos pais. Os políticos prometeram construir mais habitação a preços
+acessíveis, mas tudo avança muito devagar.
+A empresa desenvolveu uma aplicação que ajuda os utilizadores a acompanhar o seu consumo de eletricidade. A
+aplicação mostra quanta energia a casa gasta nas diferentes horas do dia e sugere quando é mais barato pôr a
+máquina a lavar ou carregar o carro. Segundo a empresa, uma família comum poderia poupar várias centenas de euros
+por ano. O serviço é gratuito durante os primeiros seis meses e depois custa quatro euros por mês.
+Quando eu era pequeno, queria ser maquinista de comboio. Colecionava bilhetes antigos e sabia de cor todas as
+estações entre Lisboa e o Porto. Mais tarde passei a interessar-me por aves, e agora trabalho como biólogo numa
+reserva natural na costa. Todas as primaveras contamos os grous que descansam nos campos antes de continuarem a
+voar para norte, e este ano eram mais do que nunca.
+Em agosto fizemos um churrasco no quintal e cantámos até escurecer. Os vizinhos vieram com pão caseiro e um grande
+pedaço de queijo, e alguém tinha trazido uma guitarra. Foi uma das melhores noites em muito tempo, e ninguém queria
+ir para casa. No próximo ano vamos tentar repetir a festa, mas no quintal dos meus pais, porque é maior.
+Este outono a escola vai criar um novo programa de apoio aos trabalhos de casa. Os alunos poderão ficar depois das
+aulas e receber ajuda dos professores e dos alunos mais velhos em matemática, português e inglês. A diretora
+espera que mais alunos passem de ano e que menos tenham de repetir. Os pais estão satisfeitos, mas alguns
+perguntam-se onde é que os professores vão arranjar tempo para isso.`
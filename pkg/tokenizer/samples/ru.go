@@ -0,0 +1,40 @@
+package samples
+
+var Russian = `Все люди рождаются свободными и равными в своем достоинстве и правах. Они наделены разумом и
+совестью и должны поступать в отношении друг друга в духе братства. Сегодня утром было холодно, поэтому мы
+остались дома и читали книги у камина. Поисковые системы строят индекс слов, которые встречаются в каждом
+документе, что позволяет быстро находить самые нужные страницы. Дети учат языки, слушая людей вокруг себя
+и пытаясь говорить сами.
+Каждое утро моя соседка гуляет с собакой вдоль реки, прежде чем пойти на работу. Городская библиотека открывается
+в девять часов и закрывается поздно вечером, и она часто полна студентов, которые готовятся к экзаменам. Обычно мы
+ужинаем вместе и разговариваем о том, что произошло за день.
+Прошлым летом мы сняли домик у озера в горах. Электричества там не было, поэтому мы готовили на дровяной печке и
+носили воду из родника за домом. По вечерам мы сидели на крыльце и смотрели на закат, а вокруг нас жужжали комары.
+Дети купались каждый день, даже когда шёл дождь, и научились грести на старой лодке до маленького острова посреди
+озера. Я собирался закончить свою книгу, но так и не продвинулся дальше второй главы.
+Вчера правительство представило новое предложение о том, как муниципалитеты должны оплачивать уход за пожилыми
+людьми. Согласно предложению, районы, где живёт много пожилых жителей, получат от государства больше денег, а
+быстро растущие города получат меньше. Оппозиция раскритиковала план и заявила, что он не решает проблему нехватки
+медсестёр. Теперь вопрос будет изучен подробнее, и решения не ожидается до осенних выборов.
+Моя бабушка родилась на хуторе недалеко от небольшой деревни на севере и выросла с семью братьями и сёстрами. Она
+часто рассказывала нам о долгих зимах, когда снега было по пояс и утром приходилось откапывать дверь лопатой. Она
+научилась вязать в пять лет и никогда не бросала это занятие, даже когда ей исполнилось девяносто. У меня до сих
+пор есть несколько её свитеров и варежек, и я вспоминаю о ней каждый раз, когда их надеваю.
+Найти квартиру в Москве трудно, особенно если ты молод и у тебя нет сбережений. Многие студенты снимают комнату и
+вынуждены переезжать несколько раз в год. Цены сильно выросли, и тому, кто хочет купить жильё, часто нужна помощь
+родителей. Политики обещали построить больше доступного жилья, но дело движется медленно.
+Компания разработала приложение, которое помогает пользователям следить за потреблением электричества. Приложение
+показывает, сколько энергии расходует семья в разные часы суток, и подсказывает, когда дешевле всего стирать бельё
+или заряжать машину. По словам компании, обычная семья может сэкономить несколько тысяч рублей в год. Первые
+полгода сервис бесплатный, а потом стоит сто рублей в месяц.
+Когда я был маленьким, я хотел стать машинистом поезда. Я собирал старые билеты и знал наизусть все станции между
+Москвой и Петербургом. Позже меня стали больше интересовать птицы, и теперь я работаю биологом в заповеднике на
+побережье. Каждую весну мы считаем журавлей, которые отдыхают на полях, прежде чем лететь дальше на север, и в этом
+году их было больше, чем когда-либо.
+В августе мы жарили шашлыки в саду и пели песни, пока не стемнело. Соседи пришли с домашним хлебом и большим куском
+сыра, а кто-то принёс гитару. Это был один из лучших вечеров за долгое время, и никто не хотел уходить домой. В
+следующем году мы попробуем устроить праздник снова, но уже в саду у моих родителей, потому что он больше.
+Этой осенью школа введёт новую программу помощи с домашними заданиями. Ученики смогут оставаться после уроков и
+получать помощь от учителей и старшеклассников по математике, русскому языку и английскому. Директор надеется, что
+больше учеников сдадут экзамены и меньше останется на второй год. Родители довольны, но некоторые спрашивают, где
+учителя найдут на это время.`
@@ -0,0 +1,48 @@
+package samples
+
+var Swedish = `Alla människor är födda fria och lika i värde och rättigheter. De har utrustats med förnuft och
+samvete och bör handla gentemot varandra i en anda av broderskap. Vädret var kallt i morse, så vi stannade hemma
+och läste böcker vid brasan. Sökmotorer bygger ett index över orden som finns i varje dokument, vilket gör det
+möjligt att snabbt hitta de mest relevanta sidorna. Barn lär sig språk genom att lyssna på människorna omkring
+dem och genom att själva försöka tala.
+Varje morgon går min granne ut med sin hund längs ån innan hon åker till jobbet. Stadens bibliotek öppnar klockan
+nio och stänger sent på kvällen, och det är ofta fullt av studenter som förbereder sig för sina tentor. Vi brukar äta
+middag tillsammans och prata om vad som hänt under dagen.
+Förra sommaren hyrde vi en stuga vid en sjö i Dalarna. Där fanns ingen el, så vi lagade maten på en vedspis och
+hämtade vatten från källan bakom huset. På kvällarna satt vi ute på bryggan och tittade på solnedgången medan
+myggorna surrade runt oss. Barnen badade varje dag, även när det regnade, och de lärde sig att ro den gamla båten
+ut till den lilla ön mitt i sjön. Jag hade tänkt skriva klart min bok, men jag kom inte längre än till andra kapitlet.
+Regeringen presenterade i går ett nytt förslag om hur kommunerna ska finansiera äldreomsorgen. Enligt förslaget
+får kommuner med många äldre invånare ett större bidrag från staten, medan de som växer snabbt får mindre.
+Oppositionen kritiserade förslaget och menade att det inte löser problemet med bristen på personal. Frågan ska
+nu utredas vidare, och ett beslut väntas först efter valet i höst.
+Min mormor föddes på en gård utanför Umeå och växte upp med sju syskon. Hon berättade ofta om de långa vintrarna,
+när snön låg meterhög och de fick skotta sig ut genom dörren på morgonen. Hon lärde sig sticka när hon var fem år
+gammal och slutade aldrig, inte ens när hon blev nittio. Fortfarande har jag flera av hennes tröjor och vantar,
+och jag tänker på henne varje gång jag tar på mig dem.
+Det är svårt att hitta en lägenhet i Stockholm, särskilt om man är ung och inte har stått länge i bostadskön.
+Många studenter bor i andra hand och måste flytta flera gånger om året. Hyrorna har stigit kraftigt, och den som
+vill köpa en bostad behöver ofta hjälp av sina föräldrar med kontantinsatsen. Politikerna har lovat att bygga fler
+hyresrätter, men det går långsamt.
+Företaget har utvecklat en app som hjälper användarna att hålla koll på sin elförbrukning. Appen visar hur mycket
+ström som går åt i hushållet under dygnets olika timmar och föreslår när det är billigast att tvätta eller ladda
+bilen. Enligt företaget kan en vanlig familj spara flera tusen kronor om året. Tjänsten är gratis under det första
+halvåret, därefter kostar den fyrtio kronor i månaden.
+När jag var liten ville jag bli lokförare. Jag samlade på tågbiljetter och kunde rabbla alla stationer mellan
+Göteborg och Kiruna utantill. Senare blev jag intresserad av fåglar i stället, och nu arbetar jag som biolog på ett
+naturreservat vid kusten. Varje vår räknar vi tranorna som vilar på fälten innan de flyger vidare norrut, och i år
+var de fler än någonsin tidigare.
+Vi åt kräftor i augusti och sjöng snapsvisor tills det blev mörkt. Grannarna kom över med hembakat bröd och en
+stor ost, och någon hade tagit med sig en gitarr. Det var en av de bästa kvällarna på länge, och ingen ville gå
+hem. Nästa år ska vi försöka ordna festen igen, men då i trädgården hos mina föräldrar, eftersom den är större.
+Skolan ska under hösten införa ett nytt system för läxhjälp. Eleverna kan stanna kvar efter lektionerna och få
+stöd av lärare och äldre elever i matematik, svenska och engelska. Rektorn hoppas att fler ska klara sina betyg,
+och att färre ska behöva gå om ett år. Föräldrarna är positiva, men en del undrar hur lärarna ska hinna med.
+På lördagarna är parken full av folk. Barnen leker i sandlådan eller springer efter duvorna, medan föräldrarna
+sitter på bänkarna och dricker kaffe. En gammal man matar ankorna vid dammen, och några tonåringar spelar fotboll
+på gräsmattan. Hunden vill alltid följa med, men den får inte bada i dammen, så den skäller på fåglarna i stället.
+Jag tycker om att gå dit tidigt, när det fortfarande är tyst och solen just har gått upp. Ibland tar jag med mig
+en bok, men oftast sitter jag bara och tittar på människorna som går förbi. Det känns som om staden vaknar långsamt
+omkring mig. Varför skulle man stanna inne när det är så vackert ute? Hur mycket kostar en kopp kaffe i kiosken
+nuförtiden? Inte särskilt mycket, men den smakar bättre än hemma. Efteråt handlar jag mat i affären på hörnet och
+cyklar hem genom skogen.`
@@ -0,0 +1,71 @@
+package snowball
+
+import (
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/tokenizer/stopwords"
+)
+
+const (
+	danishVowels  = "aeiouyæåø"
+	danishSEnding = "abcdfghjklmnoprtvyzå"
+)
+
+var danishSuffixes = []string{
+	"hed", "ethed", "ered", "e", "erede", "ende", "erende", "ene", "erne", "ere", "en", "heden", "eren", "er", "heder",
+	"erer", "heds", "es", "endes", "erendes", "enes", "ernes", "eres", "ens", "hedens", "erens", "ers", "ets", "erets",
+	"et", "eret", "s",
+}
+
+// Danish stems a Danish word with the Snowball Danish algorithm.
+func Danish(s string, stemStopWords bool) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if unstemmed(s, stopwords.Danish, stemStopWords) {
+		return s
+	}
+
+	w := word(s)
+	// the region before R1 holds at least 3 letters
+	p1 := min(max(w.region(0, danishVowels), 3), len(w))
+
+	// step 1
+	switch suffix := w.longest(p1, danishSuffixes...); suffix {
+	case "":
+	case "s":
+		if w.is(w.start(suffix)-1, danishSEnding) {
+			w.trim(suffix)
+		}
+	default:
+		w.trim(suffix)
+	}
+
+	// step 2
+	danishConsonantPair(&w, p1)
+
+	// step 3
+	if w.hasSuffix("igst") {
+		w.trim("st")
+	}
+	switch suffix := w.longest(p1, "ig", "lig", "elig", "els", "løst"); suffix {
+	case "":
+	case "løst":
+		w.replace(suffix, "løs")
+	default:
+		w.trim(suffix)
+		danishConsonantPair(&w, p1)
+	}
+
+	// step 4, a double consonant ending R1 is undoubled
+	if n := len(w); n-1 >= p1 && w.isNot(n-1, danishVowels) && w.at(n-2) == w[n-1] {
+		w = w[:n-1]
+	}
+
+	return string(w)
+}
+
+// danishConsonantPair removes the last letter of a word ending in gd, dt, gt or kt in R1.
+func danishConsonantPair(w *word, p1 int) {
+	if w.longest(p1, "gd", "dt", "gt", "kt") != "" {
+		*w = (*w)[:len(*w)-1]
+	}
+}
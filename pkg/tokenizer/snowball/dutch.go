@@ -0,0 +1,135 @@
+package snowball
+
+import (
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/tokenizer/stopwords"
+)
+
+const dutchVowels = "aeiouyè"
+
+var (
+	dutchPrelude  = strings.NewReplacer("ä", "a", "ë", "e", "ï", "i", "ö", "o", "ü", "u", "á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u")
+	dutchPostlude = strings.NewReplacer("I", "i", "Y", "y")
+)
+
+// Dutch stems a Dutch word with the Snowball Dutch algorithm.
+func Dutch(s string, stemStopWords bool) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if unstemmed(s, stopwords.Dutch, stemStopWords) {
+		return s
+	}
+
+	w := word(dutchPrelude.Replace(s))
+	// an initial y, a y after a vowel and an i between vowels are consonants
+	if w[0] == 'y' {
+		w[0] = 'Y'
+	}
+	for i := 1; i < len(w); i++ {
+		if !in(w[i-1], dutchVowels) {
+			continue
+		}
+		if w[i] == 'y' {
+			w[i] = 'Y'
+		} else if w[i] == 'i' && w.is(i+1, dutchVowels) {
+			w[i] = 'I'
+		}
+	}
+
+	p1 := w.region(0, dutchVowels)
+	p2 := w.region(p1, dutchVowels)
+	// the region before R1 holds at least 3 letters
+	p1 = min(max(p1, 3), len(w))
+
+	d := &dutchWord{word: w, p1: p1, p2: p2}
+
+	// step 1
+	switch suffix := d.longest(0, "heden", "en", "ene", "s", "se"); suffix {
+	case "heden":
+		if d.start(suffix) >= p1 {
+			d.replace(suffix, "heid")
+		}
+	case "en", "ene":
+		d.enEnding(suffix)
+	case "s", "se":
+		if start := d.start(suffix); start >= p1 && d.isNot(start-1, dutchVowels+"j") {
+			d.trim(suffix)
+		}
+	}
+
+	// step 2
+	eFound := d.eEnding()
+
+	// step 3a
+	if d.hasSuffix("heid") && d.start("heid") >= p2 && d.at(d.start("heid")-1) != 'c' {
+		d.trim("heid")
+		if d.hasSuffix("en") {
+			d.enEnding("en")
+		}
+	}
+
+	// step 3b
+	switch suffix := d.longest(0, "end", "ing", "ig", "lijk", "baar", "bar"); {
+	case suffix == "" || d.start(suffix) < p2:
+	case suffix == "end" || suffix == "ing":
+		d.trim(suffix)
+		if d.hasSuffix("ig") && d.start("ig") >= p2 && d.at(d.start("ig")-1) != 'e' {
+			d.trim("ig")
+		} else {
+			d.undouble()
+		}
+	case suffix == "ig":
+		if d.at(d.start(suffix)-1) != 'e' {
+			d.trim(suffix)
+		}
+	case suffix == "lijk":
+		d.trim(suffix)
+		d.eEnding()
+	case suffix == "baar":
+		d.trim(suffix)
+	case suffix == "bar":
+		if eFound {
+			d.trim(suffix)
+		}
+	}
+
+	// step 4, a double vowel between two consonants is undoubled
+	if n := len(d.word); d.isNot(n-1, dutchVowels+"I") && d.isNot(n-4, dutchVowels) &&
+		d.is(n-2, "aeou") && d.at(n-3) == d.at(n-2) {
+		d.word = append(d.word[:n-2], d.word[n-1])
+	}
+
+	return dutchPostlude.Replace(string(d.word))
+}
+
+type dutchWord struct {
+	word
+	p1 int
+	p2 int
+}
+
+// enEnding deletes an en or ene suffix in R1 preceded by a non-vowel other than the one of gem.
+func (d *dutchWord) enEnding(suffix string) {
+	start := d.start(suffix)
+	if start >= d.p1 && d.isNot(start-1, dutchVowels) && !d.endsWith(start, "gem") {
+		d.trim(suffix)
+		d.undouble()
+	}
+}
+
+// eEnding deletes a final e in R1 preceded by a non-vowel and reports whether it did.
+func (d *dutchWord) eEnding() bool {
+	if !d.hasSuffix("e") || d.start("e") < d.p1 || !d.isNot(d.start("e")-1, dutchVowels) {
+		return false
+	}
+	d.trim("e")
+	d.undouble()
+	return true
+}
+
+// undouble removes the last letter of a word ending in kk, dd or tt.
+func (d *dutchWord) undouble() {
+	if d.longest(0, "kk", "dd", "tt") != "" {
+		d.word = d.word[:len(d.word)-1]
+	}
+}
@@ -0,0 +1,160 @@
+package snowball
+
+import (
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/tokenizer/stopwords"
+)
+
+const (
+	finnishVowels      = "aeiouyäö"
+	finnishConsonants  = "bcdfghjklmnpqrstvwxz"
+	finnishParticleEnd = finnishVowels + "nt"
+	finnishVowelsNoY   = "aeiouäö"
+)
+
+var finnishCaseEndings = []string{
+	"han", "hen", "hin", "hon", "hän", "hön", "siin", "seen", "den", "tten", "n", "a", "ä", "tta", "ttä", "ta", "tä",
+	"ssa", "ssä", "sta", "stä", "lla", "llä", "lta", "ltä", "lle", "na", "nä", "ksi", "ine",
+}
+
+// Finnish stems a Finnish word with the Snowball Finnish algorithm.
+func Finnish(s string, stemStopWords bool) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if unstemmed(s, stopwords.Finnish, stemStopWords) {
+		return s
+	}
+
+	w := word(s)
+	p1 := w.region(0, finnishVowels)
+	p2 := w.region(p1, finnishVowels)
+
+	// step 1, the particles
+	switch suffix := w.longest(p1, "kin", "kaan", "kään", "ko", "kö", "han", "hän", "pa", "pä", "sti"); suffix {
+	case "":
+	case "sti":
+		if w.start(suffix) >= p2 {
+			w.trim(suffix)
+		}
+	default:
+		if w.is(w.start(suffix)-1, finnishParticleEnd) {
+			w.trim(suffix)
+		}
+	}
+
+	// step 2, the possessives
+	switch suffix := w.longest(p1, "si", "ni", "nsa", "nsä", "mme", "nne", "an", "än", "en"); suffix {
+	case "":
+	case "si":
+		// ksi is the translative case
+		if w.at(w.start(suffix)-1) != 'k' {
+			w.trim(suffix)
+		}
+	case "ni":
+		w.trim(suffix)
+		if w.hasSuffix("kse") {
+			w.replace("kse", "ksi")
+		}
+	case "an":
+		if w[:w.start(suffix)].longest(0, "ta", "ssa", "sta", "lla", "lta", "na") != "" {
+			w.trim(suffix)
+		}
+	case "än":
+		if w[:w.start(suffix)].longest(0, "tä", "ssä", "stä", "llä", "ltä", "nä") != "" {
+			w.trim(suffix)
+		}
+	case "en":
+		if w.endsWith(w.start(suffix), "lle") || w.endsWith(w.start(suffix), "ine") {
+			w.trim(suffix)
+		}
+	default:
+		w.trim(suffix)
+	}
+
+	// step 3, the cases
+	endingRemoved := false
+	if suffix := w.longest(p1, finnishCaseEndings...); suffix != "" {
+		start := w.start(suffix)
+		remove := true
+		switch suffix {
+		case "han", "hen", "hin", "hon", "hän", "hön":
+			// the illative repeats the vowel before it
+			remove = w.at(start-1) == []rune(suffix)[1]
+		case "siin", "den", "tten":
+			remove = w.at(start-1) == 'i' && w.is(start-2, finnishVowelsNoY)
+		case "seen":
+			remove = finnishLong(w[:start])
+		case "n":
+			// the illative after a long vowel and the genitive after ie lose their last vowel too
+			if finnishLong(w[:start]) || w.endsWith(start, "ie") {
+				start--
+			}
+		case "a", "ä":
+			remove = w.is(start-1, finnishVowels) && w.is(start-2, finnishConsonants)
+		case "tta", "ttä":
+			remove = w.at(start-1) == 'e'
+		}
+		if remove {
+			w = w[:start]
+			endingRemoved = true
+		}
+	}
+
+	// step 4, the other endings
+	switch suffix := w.longest(p2, "mpi", "mpa", "mpä", "mmi", "mma", "mmä", "impi", "impa", "impä", "immi", "imma", "immä", "eja", "ejä"); suffix {
+	case "":
+	case "mpi", "mpa", "mpä", "mmi", "mma", "mmä":
+		if !w.endsWith(w.start(suffix), "po") {
+			w.trim(suffix)
+		}
+	default:
+		w.trim(suffix)
+	}
+
+	// steps 5 and 6, the plurals
+	if endingRemoved {
+		if suffix := w.longest(p1, "i", "j"); suffix != "" {
+			w.trim(suffix)
+		}
+	} else if n := len(w); w.longest(p1, "t") != "" && n-2 >= p1 && w.is(n-2, finnishVowels) {
+		w.trim("t")
+		switch suffix := w.longest(p2, "mma", "imma"); suffix {
+		case "mma":
+			if !w.endsWith(w.start(suffix), "po") {
+				w.trim(suffix)
+			}
+		case "imma":
+			w.trim(suffix)
+		}
+	}
+
+	// step 7, tidying up in R1
+	if n := len(w); n-2 >= p1 && finnishLong(w) {
+		w = w[:n-1]
+	}
+	if n := len(w); n-2 >= p1 && w.is(n-1, "aäei") && w.is(n-2, finnishConsonants) {
+		w = w[:n-1]
+	}
+	if n := len(w); n-2 >= p1 && w[n-1] == 'j' && w.is(n-2, "ou") {
+		w = w[:n-1]
+	}
+	if n := len(w); n-2 >= p1 && w[n-1] == 'o' && w[n-2] == 'j' {
+		w = w[:n-1]
+	}
+
+	// a double consonant followed by vowels is undoubled
+	i := len(w) - 1
+	for i >= 0 && in(w[i], finnishVowels) {
+		i--
+	}
+	if w.is(i, finnishConsonants) && w.at(i-1) == w[i] {
+		w = append(w[:i], w[i+1:]...)
+	}
+
+	return string(w)
+}
+
+// finnishLong reports whether a word ends with a long vowel.
+func finnishLong(w word) bool {
+	return w.longest(0, "aa", "ee", "ii", "oo", "uu", "ää", "öö") != ""
+}
@@ -0,0 +1,95 @@
+package snowball
+
+import (
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/tokenizer/stopwords"
+)
+
+const (
+	germanVowels   = "aeiouyäöü"
+	germanSEnding  = "bdfghklmnrt"
+	germanStEnding = "bdfghklmnt"
+)
+
+var germanPostlude = strings.NewReplacer("U", "u", "Y", "y", "ä", "a", "ö", "o", "ü", "u")
+
+// German stems a German word with the Snowball German algorithm.
+func German(s string, stemStopWords bool) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if unstemmed(s, stopwords.German, stemStopWords) {
+		return s
+	}
+
+	w := word(strings.ReplaceAll(s, "ß", "ss"))
+	// u and y between vowels are consonants
+	for i := 1; i < len(w)-1; i++ {
+		if in(w[i-1], germanVowels) && in(w[i+1], germanVowels) {
+			switch w[i] {
+			case 'u':
+				w[i] = 'U'
+			case 'y':
+				w[i] = 'Y'
+			}
+		}
+	}
+
+	p1 := w.region(0, germanVowels)
+	p2 := w.region(p1, germanVowels)
+	// the region before R1 holds at least 3 letters
+	p1 = min(max(p1, 3), len(w))
+
+	// step 1
+	switch suffix := w.longest(0, "em", "ern", "er", "e", "en", "es", "s"); {
+	case suffix == "" || w.start(suffix) < p1:
+	case suffix == "s":
+		if w.is(w.start(suffix)-1, germanSEnding) {
+			w.trim(suffix)
+		}
+	case suffix == "e" || suffix == "en" || suffix == "es":
+		w.trim(suffix)
+		if w.hasSuffix("niss") {
+			w.trim("s")
+		}
+	default:
+		w.trim(suffix)
+	}
+
+	// step 2
+	switch suffix := w.longest(0, "en", "er", "est", "st"); {
+	case suffix == "" || w.start(suffix) < p1:
+	case suffix == "st":
+		// the st-ending is preceded by at least 3 letters
+		if start := w.start(suffix); w.is(start-1, germanStEnding) && start-1 >= 3 {
+			w.trim(suffix)
+		}
+	default:
+		w.trim(suffix)
+	}
+
+	// step 3
+	switch suffix := w.longest(0, "end", "ung", "ig", "ik", "isch", "lich", "heit", "keit"); {
+	case suffix == "" || w.start(suffix) < p2:
+	case suffix == "end" || suffix == "ung":
+		w.trim(suffix)
+		if w.hasSuffix("ig") && w.at(w.start("ig")-1) != 'e' && w.start("ig") >= p2 {
+			w.trim("ig")
+		}
+	case suffix == "ig" || suffix == "ik" || suffix == "isch":
+		if w.at(w.start(suffix)-1) != 'e' {
+			w.trim(suffix)
+		}
+	case suffix == "lich" || suffix == "heit":
+		w.trim(suffix)
+		if preceding := w.longest(p1, "er", "en"); preceding != "" {
+			w.trim(preceding)
+		}
+	case suffix == "keit":
+		w.trim(suffix)
+		if preceding := w.longest(0, "lich", "ig"); preceding != "" && w.start(preceding) >= p2 {
+			w.trim(preceding)
+		}
+	}
+
+	return germanPostlude.Replace(string(w))
+}
@@ -0,0 +1,171 @@
+package snowball
+
+import (
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/tokenizer/stopwords"
+)
+
+const italianVowels = "aeiouàèìòù"
+
+var (
+	italianPrelude  = strings.NewReplacer("á", "à", "é", "è", "í", "ì", "ó", "ò", "ú", "ù", "qu", "qU")
+	italianPostlude = strings.NewReplacer("I", "i", "U", "u")
+)
+
+var italianPronouns = []string{
+	"ci", "gli", "la", "le", "li", "lo", "mi", "ne", "si", "ti", "vi", "sene", "gliela", "gliele", "glieli", "glielo",
+	"gliene", "mela", "mele", "meli", "melo", "mene", "tela", "tele", "teli", "telo", "tene", "cela", "cele", "celi",
+	"celo", "cene", "vela", "vele", "veli", "velo", "vene",
+}
+
+var italianSuffixes = []string{
+	"anza", "anze", "ico", "ici", "ica", "ice", "iche", "ichi", "ismo", "ismi", "abile", "abili", "ibile", "ibili",
+	"ista", "iste", "isti", "istà", "istè", "istì", "oso", "osi", "osa", "ose", "mente", "atrice", "atrici", "ante",
+	"anti", "azione", "azioni", "atore", "atori", "logia", "logie", "uzione", "uzioni", "usione", "usioni", "enza",
+	"enze", "amento", "amenti", "imento", "imenti", "amente", "ità", "ivo", "ivi", "iva", "ive",
+}
+
+var italianVerbSuffixes = []string{
+	"ammo", "ando", "ano", "are", "arono", "asse", "assero", "assi", "assimo", "ata", "ate", "ati", "ato", "ava",
+	"avamo", "avano", "avate", "avi", "avo", "emmo", "enda", "ende", "endi", "endo", "erà", "erai", "eranno", "ere",
+	"erebbe", "erebbero", "erei", "eremmo", "eremo", "ereste", "eresti", "erete", "erò", "erono", "essero", "ete",
+	"eva", "evamo", "evano", "evate", "evi", "evo", "iamo", "immo", "irà", "irai", "iranno", "ire", "irebbe",
+	"irebbero", "irei", "iremmo", "iremo", "ireste", "iresti", "irete", "irò", "irono", "isca", "iscano", "isce",
+	"isci", "isco", "iscono", "issero", "ita", "ite", "iti", "ito", "iva", "ivamo", "ivano", "ivate", "ivi", "ivo",
+	"ar", "ir",
+}
+
+// Italian stems an Italian word with the Snowball Italian algorithm.
+func Italian(s string, stemStopWords bool) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if unstemmed(s, stopwords.Italian, stemStopWords) {
+		return s
+	}
+
+	w := word(italianPrelude.Replace(s))
+	// u and i between vowels are consonants
+	for i := 1; i < len(w)-1; i++ {
+		if in(w[i-1], italianVowels) && in(w[i+1], italianVowels) {
+			switch w[i] {
+			case 'u':
+				w[i] = 'U'
+			case 'i':
+				w[i] = 'I'
+			}
+		}
+	}
+
+	rv := w.rv(italianVowels)
+	p1 := w.region(0, italianVowels)
+	p2 := w.region(p1, italianVowels)
+
+	// step 0, the pronouns attached to a gerund or an infinitive
+	if pronoun := w.longest(0, italianPronouns...); pronoun != "" {
+		stem := w[:w.start(pronoun)]
+		switch ending := stem.longest(rv, "ando", "endo", "ar", "er", "ir"); ending {
+		case "":
+		case "ando", "endo":
+			w.trim(pronoun)
+		default:
+			w.replace(pronoun, "e")
+		}
+	}
+
+	// steps 1 and 2, the verb suffixes are only removed if no standard suffix was
+	if !italianStandardSuffix(&w, rv, p1, p2) {
+		if suffix := w.longest(rv, italianVerbSuffixes...); suffix != "" {
+			w.trim(suffix)
+		}
+	}
+
+	// step 3
+	if suffix := w.longest(rv, "a", "e", "i", "o", "à", "è", "ì", "ò"); suffix != "" {
+		w.trim(suffix)
+		if w.longest(rv, "i") != "" {
+			w.trim("i")
+		}
+	}
+	if suffix := w.longest(rv, "ch", "gh"); suffix != "" {
+		w.trim("h")
+	}
+
+	return italianPostlude.Replace(string(w))
+}
+
+// italianStandardSuffix removes the longest standard suffix and reports whether it did.
+func italianStandardSuffix(w *word, rv int, p1 int, p2 int) bool {
+	suffix := w.longest(0, italianSuffixes...)
+	if suffix == "" {
+		return false
+	}
+	start := w.start(suffix)
+
+	switch suffix {
+	case "azione", "azioni", "atore", "atori":
+		if start < p2 {
+			return false
+		}
+		w.trim(suffix)
+		if w.longest(p2, "ic") != "" {
+			w.trim("ic")
+		}
+	case "logia", "logie":
+		if start < p2 {
+			return false
+		}
+		w.replace(suffix, "log")
+	case "uzione", "uzioni", "usione", "usioni":
+		if start < p2 {
+			return false
+		}
+		w.replace(suffix, "u")
+	case "enza", "enze":
+		if start < p2 {
+			return false
+		}
+		w.replace(suffix, "ente")
+	case "amento", "amenti", "imento", "imenti":
+		if start < rv {
+			return false
+		}
+		w.trim(suffix)
+	case "amente":
+		if start < p1 {
+			return false
+		}
+		w.trim(suffix)
+		if preceding := w.longest(0, "iv", "os", "ic", "abil"); preceding != "" && w.start(preceding) >= p2 {
+			w.trim(preceding)
+			if preceding == "iv" && w.longest(p2, "at") != "" {
+				w.trim("at")
+			}
+		}
+	case "ità":
+		if start < p2 {
+			return false
+		}
+		w.trim(suffix)
+		if preceding := w.longest(0, "abil", "ic", "iv"); preceding != "" && w.start(preceding) >= p2 {
+			w.trim(preceding)
+		}
+	case "ivo", "ivi", "iva", "ive":
+		if start < p2 {
+			return false
+		}
+		w.trim(suffix)
+		if w.longest(p2, "at") != "" {
+			w.trim("at")
+			if w.longest(p2, "ic") != "" {
+				w.trim("ic")
+			}
+		}
+	default:
+		if start < p2 {
+			return false
+		}
+		w.trim(suffix)
+	}
+
+	return true
+}
@@ -0,0 +1,160 @@
+package snowball
+
+import (
+	"strings"
+
+	"github.com/micpst/minisearch/pkg/tokenizer/stopwords"
+)
+
+const portugueseVowels = "aeiouáéíóúâêô"
+
+var (
+	portuguesePrelude  = strings.NewReplacer("ã", "a~", "õ", "o~")
+	portuguesePostlude = strings.NewReplacer("a~", "ã", "o~", "õ")
+)
+
+var portugueseSuffixes = []string{
+	"eza", "ezas", "ico", "ica", "icos", "icas", "ismo", "ismos", "ável", "ível", "ista", "istas", "oso", "osa", "osos",
+	"osas", "amento", "amentos", "imento", "imentos", "adora", "ador", "aça~o", "adoras", "adores", "aço~es", "ante",
+	"antes", "ância", "logia", "logias", "uça~o", "uço~es", "ência", "ências", "amente", "mente", "idade", "idades",
+	"iva", "ivo", "ivas", "ivos", "ira", "iras",
+}
+
+var portugueseVerbSuffixes = []string{
+	"ada", "ida", "ia", "aria", "eria", "iria", "ará", "ara", "erá", "era", "irá", "ava", "asse", "esse", "isse",
+	"aste", "este", "iste", "ei", "arei", "erei", "irei", "am", "iam", "ariam", "eriam", "iriam", "aram", "eram",
+	"iram", "avam", "em", "arem", "erem", "irem", "assem", "essem", "issem", "ado", "ido", "ando", "endo", "indo",
+	"ara~o", "era~o", "ira~o", "ar", "er", "ir", "as", "adas", "idas", "ias", "arias", "erias", "irias", "arás",
+	"aras", "erás", "eras", "irás", "avas", "es", "ardes", "erdes", "irdes", "ares", "eres", "ires", "asses", "esses",
+	"isses", "astes", "estes", "istes", "is", "ais", "eis", "íeis", "aríeis", "eríeis", "iríeis", "áreis", "areis",
+	"éreis", "ereis", "íreis", "ireis", "ásseis", "ésseis", "ísseis", "áveis", "ados", "idos", "ámos", "amos",
+	"íamos", "aríamos", "eríamos", "iríamos", "áramos", "éramos", "íramos", "ávamos", "emos", "aremos", "eremos",
+	"iremos", "ássemos", "êssemos", "íssemos", "imos", "armos", "ermos", "irmos", "eu", "iu", "ou", "ira", "iras",
+}
+
+// Portuguese stems a Portuguese word with the Snowball Portuguese algorithm.
+func Portuguese(s string, stemStopWords bool) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if unstemmed(s, stopwords.Portuguese, stemStopWords) {
+		return s
+	}
+
+	// the nasal vowels are written as a vowel followed by a consonant ~
+	w := word(portuguesePrelude.Replace(s))
+
+	rv := w.rv(portugueseVowels)
+	p1 := w.region(0, portugueseVowels)
+	p2 := w.region(p1, portugueseVowels)
+
+	// steps 1 and 2, the verb suffixes are only removed if no standard suffix was
+	removed := portugueseStandardSuffix(&w, rv, p1, p2)
+	if !removed {
+		if suffix := w.longest(rv, portugueseVerbSuffixes...); suffix != "" {
+			w.trim(suffix)
+			removed = true
+		}
+	}
+
+	if removed {
+		// step 3
+		if w.longest(rv, "i") != "" && w.at(len(w)-2) == 'c' {
+			w.trim("i")
+		}
+	} else {
+		// step 4
+		if suffix := w.longest(0, "os", "a", "i", "o", "á", "í", "ó"); suffix != "" && w.start(suffix) >= rv {
+			w.trim(suffix)
+		}
+	}
+
+	// step 5
+	switch suffix := w.longest(0, "e", "é", "ê", "ç"); suffix {
+	case "ç":
+		w.replace(suffix, "c")
+	case "e", "é", "ê":
+		if w.start(suffix) < rv {
+			break
+		}
+		w.trim(suffix)
+		if (w.hasSuffix("gu") || w.hasSuffix("ci")) && len(w)-1 >= rv {
+			w = w[:len(w)-1]
+		}
+	}
+
+	return portuguesePostlude.Replace(string(w))
+}
+
+// portugueseStandardSuffix removes the longest standard suffix and reports whether it did.
+func portugueseStandardSuffix(w *word, rv int, p1 int, p2 int) bool {
+	suffix := w.longest(0, portugueseSuffixes...)
+	if suffix == "" {
+		return false
+	}
+	start := w.start(suffix)
+
+	switch suffix {
+	case "logia", "logias":
+		if start < p2 {
+			return false
+		}
+		w.replace(suffix, "log")
+	case "uça~o", "uço~es":
+		if start < p2 {
+			return false
+		}
+		w.replace(suffix, "u")
+	case "ência", "ências":
+		if start < p2 {
+			return false
+		}
+		w.replace(suffix, "ente")
+	case "amente":
+		if start < p1 {
+			return false
+		}
+		w.trim(suffix)
+		if preceding := w.longest(0, "iv", "os", "ic", "ad"); preceding != "" && w.start(preceding) >= p2 {
+			w.trim(preceding)
+			if preceding == "iv" && w.longest(p2, "at") != "" {
+				w.trim("at")
+			}
+		}
+	case "mente":
+		if start < p2 {
+			return false
+		}
+		w.trim(suffix)
+		if preceding := w.longest(0, "ante", "avel", "ível"); preceding != "" && w.start(preceding) >= p2 {
+			w.trim(preceding)
+		}
+	case "idade", "idades":
+		if start < p2 {
+			return false
+		}
+		w.trim(suffix)
+		if preceding := w.longest(0, "abil", "ic", "iv"); preceding != "" && w.start(preceding) >= p2 {
+			w.trim(preceding)
+		}
+	case "iva", "ivo", "ivas", "ivos":
+		if start < p2 {
+			return false
+		}
+		w.trim(suffix)
+		if w.longest(p2, "at") != "" {
+			w.trim("at")
+		}
+	case "ira", "iras":
+		// -eira and -eiras are usually not verbal
+		if start < rv || w.at(start-1) != 'e' {
+			return false
+		}
+		w.replace(suffix, "ir")
+	default:
+		if start < p2 {
+			return false
+		}
+		w.trim(suffix)
+	}
+
+	return true
+}
@@ -0,0 +1,116 @@
+// Package snowball ports the Snowball stemming algorithms of the languages missing from kljensen/snowball.
+// See https://snowballstem.org/algorithms/ for their description.
+package snowball
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// word is a word being stemmed, as runes so that the regions are counted in letters.
+type word []rune
+
+// unstemmed reports whether a word is left as it is: the short words, and the stop words unless they are stemmed.
+func unstemmed(s string, stopWords map[string]struct{}, stemStopWords bool) bool {
+	_, ok := stopWords[s]
+	return len(s) <= 2 || ok && !stemStopWords
+}
+
+func in(r rune, group string) bool {
+	return strings.ContainsRune(group, r)
+}
+
+// at returns the letter at position i, or zero if there is none.
+func (w word) at(i int) rune {
+	if i < 0 || i >= len(w) {
+		return 0
+	}
+	return w[i]
+}
+
+// is reports whether there is a letter at position i and it belongs to the group.
+func (w word) is(i int, group string) bool {
+	return i >= 0 && i < len(w) && in(w[i], group)
+}
+
+// isNot reports whether there is a letter at position i and it does not belong to the group.
+func (w word) isNot(i int, group string) bool {
+	return i >= 0 && i < len(w) && !in(w[i], group)
+}
+
+// endsWith reports whether the part of the word before position end ends with the suffix.
+func (w word) endsWith(end int, suffix string) bool {
+	return end >= 0 && end <= len(w) && w[:end].hasSuffix(suffix)
+}
+
+func (w word) hasSuffix(suffix string) bool {
+	n := utf8.RuneCountInString(suffix)
+	return len(w) >= n && string(w[len(w)-n:]) == suffix
+}
+
+// start returns the position of a suffix of the word.
+func (w word) start(suffix string) int {
+	return len(w) - utf8.RuneCountInString(suffix)
+}
+
+// longest returns the longest of the suffixes ending the word and starting at or after limit,
+// or an empty string if there is none.
+func (w word) longest(limit int, suffixes ...string) string {
+	longest := ""
+	for _, suffix := range suffixes {
+		if len(suffix) > len(longest) && w.hasSuffix(suffix) && w.start(suffix) >= limit {
+			longest = suffix
+		}
+	}
+	return longest
+}
+
+// replace replaces a suffix of the word.
+func (w *word) replace(suffix string, replacement string) {
+	*w = append((*w)[:w.start(suffix)], []rune(replacement)...)
+}
+
+func (w *word) trim(suffix string) {
+	*w = (*w)[:w.start(suffix)]
+}
+
+// region returns the start of the region after the first non-vowel following a vowel from the position start,
+// or the end of the word if there is none. It gives R1 from the start of the word and R2 from R1.
+func (w word) region(start int, vowels string) int {
+	for i := start + 1; i < len(w); i++ {
+		if !in(w[i], vowels) && in(w[i-1], vowels) {
+			return i + 1
+		}
+	}
+	return len(w)
+}
+
+// rv returns the start of the region RV of the Romance languages. If the second letter is a consonant, it is
+// the region after the next vowel, if the first two letters are vowels, after the next consonant,
+// and otherwise after the third letter.
+func (w word) rv(vowels string) int {
+	if len(w) < 2 {
+		return len(w)
+	}
+
+	if in(w[1], vowels) == in(w[0], vowels) {
+		// after the next consonant of two vowels or the next vowel of two consonants
+		vowel := !in(w[0], vowels)
+		for i := 2; i < len(w); i++ {
+			if in(w[i], vowels) == vowel {
+				return i + 1
+			}
+		}
+		return len(w)
+	}
+	if in(w[1], vowels) {
+		return min(3, len(w))
+	}
+	// a vowel followed by a consonant
+	for i := 2; i < len(w); i++ {
+		if in(w[i], vowels) {
+			return i + 1
+		}
+	}
+	return len(w)
+}
@@ -8,16 +8,23 @@ import (
 	"github.com/kljensen/snowball/russian"
 	"github.com/kljensen/snowball/spanish"
 	"github.com/kljensen/snowball/swedish"
+	"github.com/micpst/minisearch/pkg/tokenizer/snowball"
 )
 
 type Stem func(string, bool) string
 
 var stems = map[Language]Stem{
-	ENGLISH:   english.Stem,
-	FRENCH:    french.Stem,
-	HUNGARIAN: hungarian.Stem,
-	NORWEGIAN: norwegian.Stem,
-	RUSSIAN:   russian.Stem,
-	SPANISH:   spanish.Stem,
-	SWEDISH:   swedish.Stem,
+	DANISH:     snowball.Danish,
+	DUTCH:      snowball.Dutch,
+	ENGLISH:    english.Stem,
+	FINNISH:    snowball.Finnish,
+	FRENCH:     french.Stem,
+	GERMAN:     snowball.German,
+	HUNGARIAN:  hungarian.Stem,
+	ITALIAN:    snowball.Italian,
+	NORWEGIAN:  norwegian.Stem,
+	PORTUGUESE: snowball.Portuguese,
+	RUSSIAN:    russian.Stem,
+	SPANISH:    spanish.Stem,
+	SWEDISH:    swedish.Stem,
 }
@@ -7,11 +7,17 @@ import (
 type StopWords map[string]struct{}
 
 var stopWords = map[Language]StopWords{
-	ENGLISH:   stopwords.English,
-	FRENCH:    stopwords.French,
-	HUNGARIAN: stopwords.Hungarian,
-	NORWEGIAN: stopwords.Norwegian,
-	RUSSIAN:   stopwords.Russian,
-	SPANISH:   stopwords.Spanish,
-	SWEDISH:   stopwords.Swedish,
+	DANISH:     stopwords.Danish,
+	DUTCH:      stopwords.Dutch,
+	ENGLISH:    stopwords.English,
+	FINNISH:    stopwords.Finnish,
+	FRENCH:     stopwords.French,
+	GERMAN:     stopwords.German,
+	HUNGARIAN:  stopwords.Hungarian,
+	ITALIAN:    stopwords.Italian,
+	NORWEGIAN:  stopwords.Norwegian,
+	PORTUGUESE: stopwords.Portuguese,
+	RUSSIAN:    stopwords.Russian,
+	SPANISH:    stopwords.Spanish,
+	SWEDISH:    stopwords.Swedish,
 }
@@ -0,0 +1,233 @@
+package stopwords
+
+var Finnish = map[string]struct{}{
+	"ei":       {},
+	"eivät":    {},
+	"emme":     {},
+	"en":       {},
+	"et":       {},
+	"että":     {},
+	"ette":     {},
+	"hän":      {},
+	"häneen":   {},
+	"hänellä":  {},
+	"hänelle":  {},
+	"häneltä":  {},
+	"hänen":    {},
+	"hänessä":  {},
+	"hänestä":  {},
+	"hänet":    {},
+	"häntä":    {},
+	"he":       {},
+	"heidän":   {},
+	"heidät":   {},
+	"heihin":   {},
+	"heillä":   {},
+	"heille":   {},
+	"heiltä":   {},
+	"heissä":   {},
+	"heistä":   {},
+	"heitä":    {},
+	"itse":     {},
+	"ja":       {},
+	"johon":    {},
+	"joiden":   {},
+	"joihin":   {},
+	"joiksi":   {},
+	"joilla":   {},
+	"joille":   {},
+	"joilta":   {},
+	"joina":    {},
+	"joissa":   {},
+	"joista":   {},
+	"joita":    {},
+	"joka":     {},
+	"joksi":    {},
+	"jolla":    {},
+	"jolle":    {},
+	"jolta":    {},
+	"jona":     {},
+	"jonka":    {},
+	"jos":      {},
+	"jossa":    {},
+	"josta":    {},
+	"jota":     {},
+	"jotka":    {},
+	"kanssa":   {},
+	"keiden":   {},
+	"keihin":   {},
+	"keiksi":   {},
+	"keillä":   {},
+	"keille":   {},
+	"keiltä":   {},
+	"keinä":    {},
+	"keissä":   {},
+	"keistä":   {},
+	"keitä":    {},
+	"keneen":   {},
+	"keneksi":  {},
+	"kenellä":  {},
+	"kenelle":  {},
+	"keneltä":  {},
+	"kenen":    {},
+	"kenenä":   {},
+	"kenessä":  {},
+	"kenestä":  {},
+	"kenet":    {},
+	"ketä":     {},
+	"ketkä":    {},
+	"koska":    {},
+	"kuin":     {},
+	"kuka":     {},
+	"kun":      {},
+	"me":       {},
+	"meidän":   {},
+	"meidät":   {},
+	"meihin":   {},
+	"meillä":   {},
+	"meille":   {},
+	"meiltä":   {},
+	"meissä":   {},
+	"meistä":   {},
+	"meitä":    {},
+	"mihin":    {},
+	"mikä":     {},
+	"miksi":    {},
+	"millä":    {},
+	"mille":    {},
+	"miltä":    {},
+	"minä":     {},
+	"minkä":    {},
+	"minua":    {},
+	"minulla":  {},
+	"minulle":  {},
+	"minulta":  {},
+	"minun":    {},
+	"minussa":  {},
+	"minusta":  {},
+	"minut":    {},
+	"minuun":   {},
+	"missä":    {},
+	"mistä":    {},
+	"mitä":     {},
+	"mitkä":    {},
+	"mukaan":   {},
+	"mutta":    {},
+	"näiden":   {},
+	"näihin":   {},
+	"näiksi":   {},
+	"näillä":   {},
+	"näille":   {},
+	"näiltä":   {},
+	"näinä":    {},
+	"näissä":   {},
+	"näistä":   {},
+	"näitä":    {},
+	"nämä":     {},
+	"ne":       {},
+	"niiden":   {},
+	"niihin":   {},
+	"niiksi":   {},
+	"niillä":   {},
+	"niille":   {},
+	"niiltä":   {},
+	"niin":     {},
+	"niinä":    {},
+	"niissä":   {},
+	"niistä":   {},
+	"niitä":    {},
+	"noiden":   {},
+	"noihin":   {},
+	"noiksi":   {},
+	"noilla":   {},
+	"noille":   {},
+	"noilta":   {},
+	"noin":     {},
+	"noina":    {},
+	"noissa":   {},
+	"noista":   {},
+	"noita":    {},
+	"nuo":      {},
+	"nyt":      {},
+	"ole":      {},
+	"olemme":   {},
+	"olen":     {},
+	"olet":     {},
+	"olette":   {},
+	"oli":      {},
+	"olimme":   {},
+	"olin":     {},
+	"olisi":    {},
+	"olisimme": {},
+	"olisin":   {},
+	"olisit":   {},
+	"olisitte": {},
+	"olisivat": {},
+	"olit":     {},
+	"olitte":   {},
+	"olivat":   {},
+	"olla":     {},
+	"olleet":   {},
+	"ollut":    {},
+	"on":       {},
+	"ovat":     {},
+	"poikki":   {},
+	"se":       {},
+	"sekä":     {},
+	"sen":      {},
+	"siihen":   {},
+	"siinä":    {},
+	"siitä":    {},
+	"siksi":    {},
+	"sillä":    {},
+	"sille":    {},
+	"siltä":    {},
+	"sinä":     {},
+	"sinua":    {},
+	"sinulla":  {},
+	"sinulle":  {},
+	"sinulta":  {},
+	"sinun":    {},
+	"sinussa":  {},
+	"sinusta":  {},
+	"sinut":    {},
+	"sinuun":   {},
+	"sitä":     {},
+	"tähän":    {},
+	"tai":      {},
+	"täksi":    {},
+	"tällä":    {},
+	"tälle":    {},
+	"tältä":    {},
+	"tämä":     {},
+	"tämän":    {},
+	"tänä":     {},
+	"tässä":    {},
+	"tästä":    {},
+	"tätä":     {},
+	"te":       {},
+	"teidän":   {},
+	"teidät":   {},
+	"teihin":   {},
+	"teillä":   {},
+	"teille":   {},
+	"teiltä":   {},
+	"teissä":   {},
+	"teistä":   {},
+	"teitä":    {},
+	"tuo":      {},
+	"tuohon":   {},
+	"tuoksi":   {},
+	"tuolla":   {},
+	"tuolle":   {},
+	"tuolta":   {},
+	"tuon":     {},
+	"tuona":    {},
+	"tuossa":   {},
+	"tuosta":   {},
+	"tuota":    {},
+	"vaan":     {},
+	"vai":      {},
+	"vaikka":   {},
+	"yli":      {},
+}
@@ -2,7 +2,6 @@ package tokenizer
 
 import (
 	"regexp"
-	"strings"
 	"unicode"
 
 	"golang.org/x/text/runes"
@@ -11,23 +10,35 @@ import (
 )
 
 const (
-	ENGLISH   Language = "en"
-	FRENCH    Language = "fr"
-	HUNGARIAN Language = "hu"
-	NORWEGIAN Language = "no"
-	RUSSIAN   Language = "ru"
-	SPANISH   Language = "es"
-	SWEDISH   Language = "sv"
+	DANISH     Language = "da"
+	DUTCH      Language = "nl"
+	ENGLISH    Language = "en"
+	FINNISH    Language = "fi"
+	FRENCH     Language = "fr"
+	GERMAN     Language = "de"
+	HUNGARIAN  Language = "hu"
+	ITALIAN    Language = "it"
+	NORWEGIAN  Language = "no"
+	PORTUGUESE Language = "pt"
+	RUSSIAN    Language = "ru"
+	SPANISH    Language = "es"
+	SWEDISH    Language = "sv"
 )
 
 var splitRules = map[Language]*regexp.Regexp{
-	ENGLISH:   regexp.MustCompile(`[^A-Za-zàèéìòóù0-9_'-]`),
-	FRENCH:    regexp.MustCompile(`[^a-z0-9äâàéèëêïîöôùüûœç-]`),
-	HUNGARIAN: regexp.MustCompile(`[^a-z0-9áéíóöőúüűÁÉÍÓÖŐÚÜŰ]`),
-	NORWEGIAN: regexp.MustCompile(`[^a-z0-9_æøåÆØÅäÄöÖüÜ]`),
-	RUSSIAN:   regexp.MustCompile(`[^a-z0-9а-яА-ЯёЁ]`),
-	SPANISH:   regexp.MustCompile(`[^a-z0-9A-Zá-úÁ-ÚñÑüÜ]`),
-	SWEDISH:   regexp.MustCompile(`[^a-z0-9_åÅäÄöÖüÜ-]`),
+	DANISH:     regexp.MustCompile(`[^a-z0-9_æøåÆØÅäÄöÖüÜ]`),
+	DUTCH:      regexp.MustCompile(`[^a-z0-9_äëïöüáéíóúèÄËÏÖÜÁÉÍÓÚÈ]`),
+	ENGLISH:    regexp.MustCompile(`[^A-Za-zàèéìòóù0-9_'-]`),
+	FINNISH:    regexp.MustCompile(`[^a-z0-9_äöåÄÖÅ-]`),
+	FRENCH:     regexp.MustCompile(`[^a-z0-9äâàéèëêïîöôùüûœç-]`),
+	GERMAN:     regexp.MustCompile(`[^a-z0-9_äöüßÄÖÜ]`),
+	HUNGARIAN:  regexp.MustCompile(`[^a-z0-9áéíóöőúüűÁÉÍÓÖŐÚÜŰ]`),
+	ITALIAN:    regexp.MustCompile(`[^a-z0-9_àáèéìíîòóùúÀÈÉÌÒÙ]`),
+	NORWEGIAN:  regexp.MustCompile(`[^a-z0-9_æøåÆØÅäÄöÖüÜ]`),
+	PORTUGUESE: regexp.MustCompile(`[^a-z0-9_àáâãçéêíóôõúüÀÁÂÃÇÉÊÍÓÔÕÚÜ]`),
+	RUSSIAN:    regexp.MustCompile(`[^a-z0-9а-яА-ЯёЁ]`),
+	SPANISH:    regexp.MustCompile(`[^a-z0-9A-Zá-úÁ-ÚñÑüÜ]`),
+	SWEDISH:    regexp.MustCompile(`[^a-z0-9_åÅäÄöÖüÜ-]`),
 }
 
 var normalizer = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
@@ -45,58 +56,42 @@ type TokenizeParams struct {
 	AllowDuplicates bool
 }
 
-type normalizeParams struct {
-	token    string
-	language Language
+// Token is a normalized token with the byte offsets of the word it comes from in the original text.
+type Token struct {
+	Value string
+	Start int
+	End   int
 }
 
 func IsSupportedLanguage(language Language) bool {
+	languagesMutex.RLock()
+	defer languagesMutex.RUnlock()
+
 	_, ok := splitRules[language]
 	return ok
 }
 
 func Tokenize(params *TokenizeParams, config *Config) ([]string, error) {
-	splitRule, ok := splitRules[params.Language]
-	if !ok {
-		return nil, &LanguageNotSupportedError{params.Language}
-	}
-
-	params.Text = strings.ToLower(params.Text)
-	splitText := splitRule.Split(params.Text, -1)
-
-	tokens := make([]string, 0)
-	uniqueTokens := make(map[string]struct{})
-
-	for _, token := range splitText {
-		normParams := normalizeParams{
-			token:    token,
-			language: params.Language,
-		}
-		if normToken := normalizeToken(&normParams, config); normToken != "" {
-			if _, ok := uniqueTokens[normToken]; (!ok && !params.AllowDuplicates) || params.AllowDuplicates {
-				uniqueTokens[normToken] = struct{}{}
-				tokens = append(tokens, normToken)
-			}
-		}
+	analyzer, err := NewAnalyzer(params.Language, config)
+	if err != nil {
+		return nil, err
 	}
 
-	return tokens, nil
+	return analyzer.Tokenize(&AnalyzeParams{
+		Text:            params.Text,
+		AllowDuplicates: params.AllowDuplicates,
+	}), nil
 }
 
-func normalizeToken(params *normalizeParams, config *Config) string {
-	token := params.token
-
-	if _, ok := stopWords[params.language][token]; config.EnableStopWords && ok {
-		return ""
-	}
-
-	if stem, ok := stems[params.language]; config.EnableStemming && ok {
-		token = stem(token, false)
-	}
-
-	if normToken, _, err := transform.String(normalizer, token); err == nil {
-		return normToken
+// TokenizeWithOffsets returns the same tokens as Tokenize, along with their offsets in params.Text.
+func TokenizeWithOffsets(params *TokenizeParams, config *Config) ([]Token, error) {
+	analyzer, err := NewAnalyzer(params.Language, config)
+	if err != nil {
+		return nil, err
 	}
 
-	return token
+	return analyzer.TokenizeWithOffsets(&AnalyzeParams{
+		Text:            params.Text,
+		AllowDuplicates: params.AllowDuplicates,
+	}), nil
 }
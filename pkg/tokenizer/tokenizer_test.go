@@ -2,6 +2,8 @@ package tokenizer
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -119,3 +121,318 @@ func TestTokenize(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenizeWithOffsets(t *testing.T) {
+	cases := []TestCase[TokenizeInput, []Token]{
+		{
+			given: TokenizeInput{
+				params: TokenizeParams{
+					Text:            "The Silicon Brains, brains!",
+					Language:        ENGLISH,
+					AllowDuplicates: true,
+				},
+				config: Config{
+					EnableStemming:  true,
+					EnableStopWords: true,
+				},
+			},
+			expected: []Token{
+				{Value: "silicon", Start: 4, End: 11},
+				{Value: "brain", Start: 12, End: 18},
+				{Value: "brain", Start: 20, End: 26},
+			},
+		},
+		{
+			given: TokenizeInput{
+				params: TokenizeParams{
+					Text:            "Élan, ÉLAN élan",
+					Language:        FRENCH,
+					AllowDuplicates: false,
+				},
+				config: Config{},
+			},
+			expected: []Token{
+				{Value: "elan", Start: 0, End: 5},
+			},
+		},
+		{
+			given: TokenizeInput{
+				params: TokenizeParams{
+					Text:            "Ѐ İstanbul",
+					Language:        ENGLISH,
+					AllowDuplicates: true,
+				},
+				config: Config{},
+			},
+			expected: []Token{
+				{Value: "istanbul", Start: 3, End: 12},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("'%v'", c.given), func(t *testing.T) {
+			actual, err := TokenizeWithOffsets(&c.given.params, &c.given.config)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+
+			tokens, _ := Tokenize(&c.given.params, &c.given.config)
+			for i, token := range actual {
+				assert.Equal(t, tokens[i], token.Value)
+			}
+		})
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	english, _ := NewAnalyzer(ENGLISH, &Config{EnableStemming: true, EnableStopWords: true})
+
+	cases := []TestCase[Analyzer, []Token]{
+		{
+			given: *english,
+			expected: []Token{
+				{Value: "hous", Start: 4, End: 9},
+				{Value: "red", Start: 14, End: 18},
+			},
+		},
+		{
+			given: Analyzer{},
+			expected: []Token{
+				{Value: "The", Start: 0, End: 3},
+				{Value: "House", Start: 4, End: 9},
+				{Value: "is", Start: 10, End: 12},
+				{Value: "Réd", Start: 14, End: 18},
+			},
+		},
+		{
+			given: Analyzer{
+				CharFilters: []CharFilter{
+					Lowercase,
+					CharFilterFunc(func(r rune) rune {
+						if r == 'e' {
+							return -1
+						}
+						return r
+					}),
+				},
+				TokenFilters: []TokenFilter{
+					RemoveDiacritics,
+					TokenFilterFunc(func(token string) string {
+						if len(token) < 3 {
+							return ""
+						}
+						return token
+					}),
+				},
+			},
+			expected: []Token{
+				{Value: "hous", Start: 4, End: 9},
+				{Value: "red", Start: 14, End: 18},
+			},
+		},
+	}
+	for i, c := range cases {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			params := AnalyzeParams{Text: "The House is \"Réd\"", AllowDuplicates: true}
+
+			actual := c.given.TokenizeWithOffsets(&params)
+			assert.Equal(t, c.expected, actual)
+
+			tokens := c.given.Tokenize(&params)
+			assert.Len(t, tokens, len(actual))
+			for i, token := range actual {
+				assert.Equal(t, tokens[i], token.Value)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	cases := []TestCase[string, Language]{
+		{
+			given:    "The committee will publish its annual report on the economy next week.",
+			expected: ENGLISH,
+		},
+		{
+			given:    "Les chercheurs ont étudié l'évolution du climat dans les montagnes depuis dix ans.",
+			expected: FRENCH,
+		},
+		{
+			given:    "Los investigadores analizaron los datos de la encuesta durante varios meses.",
+			expected: SPANISH,
+		},
+		{
+			given:    "Regeringen har beslutat att satsa mer pengar på skolan och vården nästa år.",
+			expected: SWEDISH,
+		},
+		{
+			given:    "Kommunen skal bygge en ny barnehage i sentrum av byen neste høst.",
+			expected: NORWEGIAN,
+		},
+		{
+			given:    "Hunden springer genom parken medan barnen leker med bollen.",
+			expected: SWEDISH,
+		},
+		{
+			given:    "Hunden løper gjennom parken mens barna leker med ballen.",
+			expected: NORWEGIAN,
+		},
+		{
+			given:    "Hunden løber gennem parken, mens børnene leger med bolden.",
+			expected: DANISH,
+		},
+		{
+			given:    "Jag vet inte vad jag ska göra i morgon.",
+			expected: SWEDISH,
+		},
+		{
+			given:    "Jeg vet ikke hva jeg skal gjøre i morgen.",
+			expected: NORWEGIAN,
+		},
+		{
+			given:    "Jeg ved ikke, hvad jeg skal gøre i morgen.",
+			expected: DANISH,
+		},
+		{
+			given:    "A kutatók több éven keresztül vizsgálták a folyó vízminőségét.",
+			expected: HUNGARIAN,
+		},
+		{
+			given:    "Учёные изучили влияние климата на урожай пшеницы в прошлом году.",
+			expected: RUSSIAN,
+		},
+		{
+			given:    "Die Forscher haben die Entwicklung des Klimas in den Bergen seit zehn Jahren untersucht.",
+			expected: GERMAN,
+		},
+		{
+			given:    "I ricercatori hanno studiato l'evoluzione del clima nelle montagne per dieci anni.",
+			expected: ITALIAN,
+		},
+		{
+			given:    "Os investigadores estudaram a evolução do clima nas montanhas durante dez anos.",
+			expected: PORTUGUESE,
+		},
+		{
+			given:    "De onderzoekers hebben de ontwikkeling van het klimaat in de bergen tien jaar lang bestudeerd.",
+			expected: DUTCH,
+		},
+		{
+			given:    "Forskerne har undersøgt udviklingen af klimaet i bjergene gennem de sidste ti år.",
+			expected: DANISH,
+		},
+		{
+			given:    "Tutkijat ovat seuranneet ilmaston muutosta vuoristossa jo kymmenen vuoden ajan.",
+			expected: FINNISH,
+		},
+		{
+			given:    "123 !?",
+			expected: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.given, func(t *testing.T) {
+			detection := DetectLanguage(c.given)
+			assert.Equal(t, c.expected, detection.Language)
+			if c.expected != "" {
+				assert.Greater(t, detection.Confidence, 0.5)
+				assert.LessOrEqual(t, detection.Confidence, 1.0)
+			}
+		})
+	}
+}
+
+func TestStem(t *testing.T) {
+	type StemInput struct {
+		language Language
+		word     string
+	}
+
+	cases := []TestCase[StemInput, string]{
+		{given: StemInput{GERMAN, "aufeinanderfolgenden"}, expected: "aufeinanderfolg"},
+		{given: StemInput{GERMAN, "häuser"}, expected: "haus"},
+		{given: StemInput{GERMAN, "erkenntnisse"}, expected: "erkenntnis"},
+		{given: StemInput{GERMAN, "möglichkeiten"}, expected: "moglich"},
+		{given: StemInput{DUTCH, "lichamelijk"}, expected: "licham"},
+		{given: StemInput{DUTCH, "mogelijkheden"}, expected: "mogelijk"},
+		{given: StemInput{DUTCH, "bibliotheek"}, expected: "bibliothek"},
+		{given: StemInput{DANISH, "indtagelse"}, expected: "indtag"},
+		{given: StemInput{DANISH, "lykkeligt"}, expected: "lyk"},
+		{given: StemInput{ITALIAN, "abbandonata"}, expected: "abbandon"},
+		{given: StemInput{ITALIAN, "guardarla"}, expected: "guard"},
+		{given: StemInput{ITALIAN, "possibilità"}, expected: "possibil"},
+		{given: StemInput{PORTUGUESE, "quilométricas"}, expected: "quilométr"},
+		{given: StemInput{PORTUGUESE, "felicidade"}, expected: "felic"},
+		{given: StemInput{PORTUGUESE, "rapidamente"}, expected: "rapid"},
+		{given: StemInput{FINNISH, "kirjastossa"}, expected: "kirjasto"},
+		{given: StemInput{FINNISH, "taloon"}, expected: "talo"},
+		{given: StemInput{FINNISH, "aatonaatto"}, expected: "aatonaato"},
+		{given: StemInput{FINNISH, "eläkkeellä"}, expected: "eläk"},
+		// the stop words are not stemmed
+		{given: StemInput{GERMAN, "würden"}, expected: "würden"},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s %s", c.given.language, c.given.word), func(t *testing.T) {
+			assert.Equal(t, c.expected, stems[c.given.language](c.given.word, false))
+		})
+	}
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	const ESPERANTO Language = "eo"
+	t.Cleanup(func() {
+		languagesMutex.Lock()
+		defer languagesMutex.Unlock()
+		delete(splitRules, ESPERANTO)
+		delete(stopWords, ESPERANTO)
+		delete(stems, ESPERANTO)
+		delete(languageSamples, ESPERANTO)
+		profiles = nil
+	})
+
+	assert.Equal(t, &InvalidLanguageError{Language: AUTO, Reason: "reserved language name"}, RegisterLanguage(AUTO, &LanguageParams{}))
+	assert.Equal(t, &InvalidLanguageError{Language: ESPERANTO, Reason: "split rule is required"}, RegisterLanguage(ESPERANTO, &LanguageParams{}))
+	assert.False(t, IsSupportedLanguage(ESPERANTO))
+
+	_, err := Fingerprint(ESPERANTO)
+	assert.Equal(t, &LanguageNotSupportedError{Language: ESPERANTO}, err)
+
+	params := &LanguageParams{
+		SplitRule: regexp.MustCompile(`[^a-z0-9ĉĝĥĵŝŭ]`),
+		StopWords: StopWords{"la": {}, "kaj": {}},
+		Stem: func(word string, _ bool) string {
+			return strings.TrimSuffix(strings.TrimSuffix(word, "j"), "o")
+		},
+		Sample: `Ĉiuj homoj estas denaske liberaj kaj egalaj laŭ digno kaj rajtoj. Ili posedas racion kaj konsciencon,
+kaj devus konduti unu al alia en spirito de frateco. La vetero estis malvarma ĉi-matene, do ni restis hejme
+kaj legis librojn apud la fajro. Ĉiun matenon mia najbarino promenigas sian hundon laŭ la rivero.
+Serĉiloj konstruas indekson de la vortoj trovitaj en ĉiu dokumento, kio ebligas rapide trovi la plej gravajn
+paĝojn. Infanoj lernas lingvojn aŭskultante la homojn ĉirkaŭ si kaj mem provante paroli. La urba biblioteko
+malfermiĝas je la naŭa kaj fermiĝas malfrue vespere, kaj ĝi ofte estas plena de studentoj, kiuj prepariĝas
+por siaj ekzamenoj. Kutime ni vespermanĝas kune kaj parolas pri tio, kio okazis dum la tago.
+Pasintan someron ni luis dometon ĉe lago en la montoj. Ne estis elektro, do ni kuiris sur ligna forno kaj
+alportis akvon el la fonto malantaŭ la domo. Vespere ni sidis sur la verando kaj rigardis la sunsubiron, dum
+la moskitoj zumis ĉirkaŭ ni. La infanoj naĝis ĉiutage, eĉ kiam pluvis, kaj ili lernis remi per la malnova
+boato ĝis la eta insulo meze de la lago.`,
+	}
+	assert.NoError(t, RegisterLanguage(ESPERANTO, params))
+	assert.True(t, IsSupportedLanguage(ESPERANTO))
+
+	tokens, err := Tokenize(&TokenizeParams{Text: "La hundoj kaj la ĉevaloj", Language: ESPERANTO}, &Config{
+		EnableStemming:  true,
+		EnableStopWords: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hund", "ceval"}, tokens)
+	assert.Equal(t, ESPERANTO, DetectLanguage("Ni legis la librojn de mia najbarino apud la rivero.").Language)
+
+	// registering the language again with other rules changes its fingerprint
+	fingerprint, err := Fingerprint(ESPERANTO)
+	assert.NoError(t, err)
+	params.Stem = nil
+	assert.NoError(t, RegisterLanguage(ESPERANTO, params))
+	changed, err := Fingerprint(ESPERANTO)
+	assert.NoError(t, err)
+	assert.NotEqual(t, fingerprint, changed)
+}
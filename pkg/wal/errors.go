@@ -0,0 +1,30 @@
+package wal
+
+import (
+	"fmt"
+	"time"
+)
+
+type SyncPolicyNotSupportedError struct {
+	Policy SyncPolicy
+}
+
+type InvalidSyncIntervalError struct {
+	Interval time.Duration
+}
+
+type CorruptedRecordError struct {
+	Offset int64
+}
+
+func (e *SyncPolicyNotSupportedError) Error() string {
+	return fmt.Sprintf("Sync policy '%s' is not supported", e.Policy)
+}
+
+func (e *InvalidSyncIntervalError) Error() string {
+	return fmt.Sprintf("Sync interval '%s' must be positive", e.Interval)
+}
+
+func (e *CorruptedRecordError) Error() string {
+	return fmt.Sprintf("Log record at offset %d is corrupted", e.Offset)
+}
@@ -0,0 +1,234 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	SyncAlways   SyncPolicy = "always"
+	SyncInterval SyncPolicy = "interval"
+	SyncNever    SyncPolicy = "never"
+)
+
+const headerSize = 8
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+type SyncPolicy string
+
+type Config struct {
+	SyncPolicy   SyncPolicy
+	SyncInterval time.Duration
+}
+
+type Log struct {
+	mutex  sync.Mutex
+	file   *os.File
+	size   int64
+	policy SyncPolicy
+	dirty  bool
+	done   chan struct{}
+	wg     sync.WaitGroup
+	closed sync.Once
+}
+
+func Open(path string, c *Config) (*Log, error) {
+	switch c.SyncPolicy {
+	case SyncAlways, SyncNever:
+	case SyncInterval:
+		if c.SyncInterval <= 0 {
+			return nil, &InvalidSyncIntervalError{Interval: c.SyncInterval}
+		}
+	default:
+		return nil, &SyncPolicyNotSupportedError{Policy: c.SyncPolicy}
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	l := &Log{
+		file:   f,
+		size:   info.Size(),
+		policy: c.SyncPolicy,
+		done:   make(chan struct{}),
+	}
+
+	if c.SyncPolicy == SyncInterval {
+		l.wg.Add(1)
+		go l.syncEvery(c.SyncInterval)
+	}
+
+	return l, nil
+}
+
+func (l *Log) Append(record []byte) error {
+	buf := make([]byte, headerSize+len(record))
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(record)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.Checksum(record, crcTable))
+	copy(buf[headerSize:], record)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, err := l.file.WriteAt(buf, l.size); err != nil {
+		// drop the partially written record so that the next append does not land after garbage
+		_ = l.file.Truncate(l.size)
+		return err
+	}
+
+	if l.policy == SyncAlways {
+		// a record that is not durable is dropped, the caller does not apply it either
+		if err := l.file.Sync(); err != nil {
+			_ = l.file.Truncate(l.size)
+			return err
+		}
+	} else {
+		l.dirty = true
+	}
+	l.size += int64(len(buf))
+
+	return nil
+}
+
+// Replay calls fn for every record in the log in the order they were appended.
+// A torn or corrupted record at the end of the log, left behind by a crash in the
+// middle of an append, is discarded. Corruption anywhere else is reported as an error.
+func (l *Log) Replay(fn func(record []byte) error) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	r := bufio.NewReader(io.NewSectionReader(l.file, 0, l.size))
+	header := make([]byte, headerSize)
+	offset := int64(0)
+
+	for offset < l.size {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return l.truncateTail(offset, err)
+		}
+
+		length := int64(binary.LittleEndian.Uint32(header[0:4]))
+		checksum := binary.LittleEndian.Uint32(header[4:8])
+		end := offset + headerSize + length
+
+		if end > l.size {
+			return l.truncateTail(offset, io.ErrUnexpectedEOF)
+		}
+
+		record := make([]byte, length)
+		if _, err := io.ReadFull(r, record); err != nil {
+			return l.truncateTail(offset, err)
+		}
+
+		if crc32.Checksum(record, crcTable) != checksum {
+			if end == l.size {
+				return l.truncateTail(offset, io.ErrUnexpectedEOF)
+			}
+			return &CorruptedRecordError{Offset: offset}
+		}
+
+		if err := fn(record); err != nil {
+			return err
+		}
+
+		offset = end
+	}
+
+	return nil
+}
+
+func (l *Log) Truncate() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.file.Truncate(0); err != nil {
+		return err
+	}
+	l.size = 0
+	l.dirty = false
+
+	return l.file.Sync()
+}
+
+func (l *Log) Sync() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.sync()
+}
+
+// Close stops the background sync and closes the file. Closing a closed log does nothing.
+func (l *Log) Close() error {
+	var err error
+	l.closed.Do(func() {
+		err = l.close()
+	})
+	return err
+}
+
+func (l *Log) close() error {
+	close(l.done)
+	l.wg.Wait()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err := l.sync(); err != nil {
+		_ = l.file.Close()
+		return err
+	}
+
+	return l.file.Close()
+}
+
+func (l *Log) sync() error {
+	if !l.dirty {
+		return nil
+	}
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	l.dirty = false
+	return nil
+}
+
+func (l *Log) syncEvery(interval time.Duration) {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-ticker.C:
+			_ = l.Sync()
+		}
+	}
+}
+
+func (l *Log) truncateTail(offset int64, err error) error {
+	if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	if err := l.file.Truncate(offset); err != nil {
+		return err
+	}
+	l.size = offset
+	return nil
+}
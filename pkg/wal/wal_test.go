@@ -0,0 +1,164 @@
+package wal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestCase[Given any, Expected any] struct {
+	given    Given
+	expected Expected
+}
+
+var testRecords = [][]byte{
+	[]byte("insert"),
+	[]byte("update"),
+	[]byte("delete"),
+}
+
+func TestOpen(t *testing.T) {
+	cases := []TestCase[Config, error]{
+		{
+			given:    Config{SyncPolicy: SyncAlways},
+			expected: nil,
+		},
+		{
+			given:    Config{SyncPolicy: SyncInterval, SyncInterval: time.Millisecond},
+			expected: nil,
+		},
+		{
+			given:    Config{SyncPolicy: SyncInterval},
+			expected: &InvalidSyncIntervalError{Interval: 0},
+		},
+		{
+			given:    Config{SyncPolicy: "sometimes"},
+			expected: &SyncPolicyNotSupportedError{Policy: "sometimes"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.given), func(t *testing.T) {
+			l, err := Open(filepath.Join(t.TempDir(), "wal"), &c.given)
+			assert.Equal(t, c.expected, err)
+			if err == nil {
+				assert.NoError(t, l.Close())
+			}
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	cases := []TestCase[func(path string), [][]byte]{
+		{
+			given:    func(path string) {},
+			expected: testRecords,
+		},
+		{
+			given: func(path string) {
+				// torn header
+				appendBytes(path, []byte{0x05, 0x00})
+			},
+			expected: testRecords,
+		},
+		{
+			given: func(path string) {
+				// torn payload
+				appendBytes(path, []byte{0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 'a'})
+			},
+			expected: testRecords,
+		},
+		{
+			given: func(path string) {
+				// complete record with a bad checksum
+				appendBytes(path, []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 'a'})
+			},
+			expected: testRecords,
+		},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%v", c.expected), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "wal")
+			writeRecords(t, path, testRecords)
+			c.given(path)
+
+			l, err := Open(path, &Config{SyncPolicy: SyncNever})
+			assert.NoError(t, err)
+
+			actual := readRecords(t, l)
+			assert.Equal(t, c.expected, actual)
+
+			// the torn tail is dropped, so new records are appended right after the valid ones
+			assert.NoError(t, l.Append([]byte("insert")))
+			assert.NoError(t, l.Close())
+
+			l, err = Open(path, &Config{SyncPolicy: SyncNever})
+			assert.NoError(t, err)
+			assert.Equal(t, append(c.expected, []byte("insert")), readRecords(t, l))
+			assert.NoError(t, l.Close())
+		})
+	}
+}
+
+func TestReplayCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+	writeRecords(t, path, testRecords)
+
+	data, _ := os.ReadFile(path)
+	data[headerSize] ^= 0xff
+	_ = os.WriteFile(path, data, 0o644)
+
+	l, err := Open(path, &Config{SyncPolicy: SyncNever})
+	assert.NoError(t, err)
+
+	err = l.Replay(func(record []byte) error { return nil })
+	assert.Equal(t, &CorruptedRecordError{Offset: 0}, err)
+	assert.NoError(t, l.Close())
+}
+
+func TestTruncate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal")
+	writeRecords(t, path, testRecords)
+
+	l, err := Open(path, &Config{SyncPolicy: SyncAlways})
+	assert.NoError(t, err)
+	assert.NoError(t, l.Truncate())
+	assert.Empty(t, readRecords(t, l))
+	assert.NoError(t, l.Close())
+}
+
+func TestClose(t *testing.T) {
+	l, err := Open(filepath.Join(t.TempDir(), "wal"), &Config{SyncPolicy: SyncInterval, SyncInterval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.NoError(t, l.Append(testRecords[0]))
+	assert.NoError(t, l.Close())
+	assert.NoError(t, l.Close())
+}
+
+func writeRecords(t *testing.T, path string, records [][]byte) {
+	l, err := Open(path, &Config{SyncPolicy: SyncAlways})
+	assert.NoError(t, err)
+	for _, record := range records {
+		assert.NoError(t, l.Append(record))
+	}
+	assert.NoError(t, l.Close())
+}
+
+func readRecords(t *testing.T, l *Log) [][]byte {
+	records := make([][]byte, 0)
+	err := l.Replay(func(record []byte) error {
+		records = append(records, record)
+		return nil
+	})
+	assert.NoError(t, err)
+	return records
+}
+
+func appendBytes(path string, data []byte) {
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	_, _ = f.Write(data)
+	_ = f.Close()
+}